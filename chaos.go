@@ -0,0 +1,110 @@
+package fakefeeder
+
+import (
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// ChaosConfig configures the faults injected by a ChaosSink. Rates are
+// probabilities in the range [0, 1] applied independently to each update.
+type ChaosConfig struct {
+	Latency time.Duration `yaml:"latency"` // added to every update
+	Jitter  time.Duration `yaml:"jitter"`  // maximum additional random latency per update
+
+	DropRate          float64 `yaml:"drop_rate"`           // update is silently discarded
+	DuplicateRate     float64 `yaml:"duplicate_rate"`      // update is sent twice
+	PublishOnlyRate   float64 `yaml:"publish_only_rate"`   // update is published without incrementing score
+	IncrementOnlyRate float64 `yaml:"increment_only_rate"` // score is incremented without publishing update
+
+	DisconnectEvery time.Duration `yaml:"disconnect_every"` // force-close all connections on this interval
+}
+
+// needsFaultSink reports whether cfg injects faults requiring a FaultSink.
+func (cfg ChaosConfig) needsFaultSink() bool {
+	return cfg.PublishOnlyRate > 0 || cfg.IncrementOnlyRate > 0 || cfg.DisconnectEvery > 0
+}
+
+// ChaosSink wraps another Sink, injecting faults into the updates sent through
+// it, in order to exercise the error handling of downstream consumers. Seeding
+// is passed through unaltered.
+type ChaosSink struct {
+	Sink
+	cfg ChaosConfig
+
+	mu             sync.Mutex
+	nextDisconnect time.Time
+}
+
+// NewChaosSink returns a ChaosSink wrapping s. If cfg enables faults beyond
+// latency, dropped or duplicated updates, s must also implement FaultSink.
+func NewChaosSink(s Sink, cfg ChaosConfig) (*ChaosSink, error) {
+	for _, r := range []float64{cfg.DropRate, cfg.DuplicateRate, cfg.PublishOnlyRate, cfg.IncrementOnlyRate} {
+		if r < 0 || r > 1 {
+			return nil, errors.New("chaos rates must be between 0 and 1")
+		}
+	}
+	if _, ok := s.(FaultSink); cfg.needsFaultSink() && !ok {
+		return nil, errors.New("sink does not support partial updates or dropping connections")
+	}
+	return &ChaosSink{
+		Sink:           s,
+		cfg:            cfg,
+		nextDisconnect: time.Now().Add(cfg.DisconnectEvery),
+	}, nil
+}
+
+// Update implements Sink, applying the configured faults.
+func (c *ChaosSink) Update(id string, tweet []byte) error {
+	if err := c.maybeDisconnect(); err != nil {
+		return err
+	}
+
+	latency := c.cfg.Latency
+	if c.cfg.Jitter > 0 {
+		latency += time.Duration(rand.Int63n(int64(c.cfg.Jitter)))
+	}
+	if latency > 0 {
+		time.Sleep(latency)
+	}
+
+	switch {
+	case chance(c.cfg.DropRate):
+		return nil
+	case chance(c.cfg.PublishOnlyRate):
+		return c.Sink.(FaultSink).Publish(id, tweet)
+	case chance(c.cfg.IncrementOnlyRate):
+		return c.Sink.(FaultSink).Increment(id)
+	case chance(c.cfg.DuplicateRate):
+		if err := c.Sink.Update(id, tweet); err != nil {
+			return err
+		}
+	}
+	return c.Sink.Update(id, tweet)
+}
+
+// maybeDisconnect drops all connections of the underlying sink if the
+// scheduled disconnect time has passed.
+func (c *ChaosSink) maybeDisconnect() error {
+	if c.cfg.DisconnectEvery <= 0 {
+		return nil
+	}
+	c.mu.Lock()
+	now := time.Now()
+	due := !now.Before(c.nextDisconnect)
+	if due {
+		c.nextDisconnect = now.Add(c.cfg.DisconnectEvery)
+	}
+	c.mu.Unlock()
+
+	if !due {
+		return nil
+	}
+	return c.Sink.(FaultSink).DropConnections()
+}
+
+// chance returns true with probability p.
+func chance(p float64) bool {
+	return p > 0 && rand.Float64() < p
+}
@@ -0,0 +1,271 @@
+package fakefeeder
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"sync/atomic"
+
+	"github.com/mroth/weightedrand"
+)
+
+// Chooser picks the emoji for each random update sent by a Feeder.
+//
+// Implementations must be safe for concurrent use by multiple goroutines. The
+// Choosers in this package rely on the top-level math/rand functions, which
+// are.
+type Chooser interface {
+	// Choose returns the emoji for the next update.
+	Choose() Ranking
+}
+
+// Observer may be implemented by a Chooser whose choices depend on the updates
+// sent, such as under RichGetRicher.
+type Observer interface {
+	// Observe is called after each successful update for emoji, whether or
+	// not it was chosen by the Chooser.
+	Observe(emoji Ranking)
+}
+
+// errNoRankings is returned when building a Chooser with nothing to choose.
+var errNoRankings = errors.New("no rankings to choose from")
+
+// newChooser returns the Chooser for rs configured by opts.
+func newChooser(rs []Ranking, opts Options) (Chooser, error) {
+	if len(rs) == 0 {
+		return nil, errNoRankings
+	}
+	if opts.Chooser != nil {
+		return opts.Chooser(rs)
+	}
+
+	weighting := opts.Weighting
+	if weighting == nil {
+		weighting = Uniform()
+		if opts.Weighted {
+			weighting = Proportional()
+		}
+	}
+	weights, err := weighting(rs)
+	if err != nil {
+		return nil, err
+	}
+
+	switch opts.Dynamics {
+	case Static:
+		return NewWeightedChooser(rs, weights)
+	case RichGetRicher:
+		return newFenwickChooser(rs, weights, opts.Gain)
+	case Flatten:
+		base, err := NewWeightedChooser(rs, weights)
+		if err != nil {
+			return nil, err
+		}
+		return newFlattenChooser(base, rs, opts.FlattenHalfLife), nil
+	}
+	return nil, fmt.Errorf("unknown dynamics %v", opts.Dynamics)
+}
+
+// Weighting assigns a relative weight to each of rs, determining the
+// probability of it being chosen for an update.
+type Weighting func(rs []Ranking) ([]float64, error)
+
+// Uniform returns a Weighting giving every emoji the same weight.
+func Uniform() Weighting {
+	return func(rs []Ranking) ([]float64, error) {
+		ws := make([]float64, len(rs))
+		for i := range ws {
+			ws[i] = 1
+		}
+		return ws, nil
+	}
+}
+
+// Proportional returns a Weighting equal to the Score of each emoji.
+func Proportional() Weighting {
+	return scoreWeighting(func(score float64) float64 { return score })
+}
+
+// Temperature returns a Weighting of score^alpha for each emoji. An alpha of 1
+// is Proportional, smaller values flatten the distribution toward Uniform (at
+// 0), and larger values exaggerate the lead of the most popular emoji.
+//
+// Scores are divided by the highest score before being raised to alpha, which
+// leaves the relative weights unchanged but keeps them from overflowing.
+func Temperature(alpha float64) Weighting {
+	return func(rs []Ranking) ([]float64, error) {
+		var max int64
+		for _, r := range rs {
+			if r.Score > max {
+				max = r.Score
+			}
+		}
+		return scoreWeighting(func(score float64) float64 {
+			if max == 0 {
+				return math.Pow(score, alpha)
+			}
+			return math.Pow(score/float64(max), alpha)
+		})(rs)
+	}
+}
+
+// LogScore returns a Weighting of log(1+score) for each emoji, a much flatter
+// distribution than Proportional which still favors popular emoji.
+func LogScore() Weighting {
+	return scoreWeighting(math.Log1p)
+}
+
+// Zipf returns a Weighting of 1/rank^s for each emoji, where rank is its
+// position (from 1) when ordered by descending score. This keeps the order of
+// popularity while replacing the shape of the distribution.
+func Zipf(s float64) Weighting {
+	return func(rs []Ranking) ([]float64, error) {
+		if err := checkScores(rs); err != nil {
+			return nil, err
+		}
+		order := make([]int, len(rs))
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(a, b int) bool {
+			return rs[order[a]].Score > rs[order[b]].Score
+		})
+		ws := make([]float64, len(rs))
+		for rank, i := range order {
+			ws[i] = 1 / math.Pow(float64(rank+1), s)
+		}
+		return ws, nil
+	}
+}
+
+// scoreWeighting returns a Weighting of f(score) for each emoji.
+func scoreWeighting(f func(score float64) float64) Weighting {
+	return func(rs []Ranking) ([]float64, error) {
+		if err := checkScores(rs); err != nil {
+			return nil, err
+		}
+		ws := make([]float64, len(rs))
+		for i, r := range rs {
+			ws[i] = f(float64(r.Score))
+		}
+		return ws, nil
+	}
+}
+
+// checkScores returns an error if any of rs has a negative score, which would
+// otherwise produce a meaningless weight.
+func checkScores(rs []Ranking) error {
+	for _, r := range rs {
+		if r.Score < 0 {
+			return fmt.Errorf("negative score %d for %s", r.Score, r.ID)
+		}
+	}
+	return nil
+}
+
+// NewWeightedChooser returns a Chooser picking each of rs with probability
+// proportional to the corresponding weight. Weights must be finite and
+// non-negative; if they are all equal (including all zero), the choice is
+// uniform.
+func NewWeightedChooser(rs []Ranking, weights []float64) (Chooser, error) {
+	if len(rs) == 0 {
+		return nil, errNoRankings
+	}
+	total, err := checkWeights(rs, weights)
+	if err != nil {
+		return nil, err
+	}
+
+	uniform := true
+	for _, w := range weights {
+		uniform = uniform && w == weights[0]
+	}
+	if uniform {
+		return uniformChooser(rs), nil
+	}
+	if len(rs) > weightTotal {
+		return nil, fmt.Errorf("too many rankings to weight (%d)", len(rs))
+	}
+
+	// weighted random distribution (using github.com/mroth/weightedrand)
+	//
+	// Its integer weights must not sum to more than the maximum int, which is
+	// easily exceeded by raw scores on 32-bit platforms, so weights are scaled
+	// to sum to about weightTotal. Any positive weight is kept at least 1, so
+	// that no emoji with a chance of being chosen is lost to rounding.
+	choices := make([]weightedrand.Choice, 0, len(rs))
+	for i, w := range scaleWeights(weights, total) {
+		choices = append(choices, weightedrand.Choice{Item: rs[i], Weight: w})
+	}
+	chooser, err := weightedrand.NewChooser(choices...)
+	if err != nil {
+		return nil, err
+	}
+	return weightedChooser{chooser}, nil
+}
+
+// weightTotal is the approximate sum of the integer weights in a weighted
+// Chooser. Rounding each weight can add at most 1 per emoji, so the sum stays
+// well below the maximum int on all platforms while len(rs) <= weightTotal.
+const weightTotal = 1 << 29
+
+// scaleWeights converts weights summing to total into integer weights summing
+// to about weightTotal, keeping any positive weight at least 1.
+func scaleWeights(weights []float64, total float64) []uint {
+	scaled := make([]uint, len(weights))
+	for i, w := range weights {
+		scaled[i] = uint(math.Round(w / total * weightTotal))
+		if scaled[i] == 0 && w > 0 {
+			scaled[i] = 1
+		}
+	}
+	return scaled
+}
+
+// checkWeights returns an error unless there is a finite, non-negative weight
+// for each of rs, along with the sum of the weights.
+func checkWeights(rs []Ranking, weights []float64) (float64, error) {
+	if len(weights) != len(rs) {
+		return 0, fmt.Errorf("have %d weights for %d rankings", len(weights), len(rs))
+	}
+	var total float64
+	for i, w := range weights {
+		if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+			return 0, fmt.Errorf("invalid weight %v for %s", w, rs[i].ID)
+		}
+		total += w
+	}
+	if math.IsInf(total, 0) {
+		return 0, errors.New("sum of weights overflows")
+	}
+	return total, nil
+}
+
+type weightedChooser struct{ c *weightedrand.Chooser }
+
+func (c weightedChooser) Choose() Ranking { return c.c.Pick().(Ranking) }
+
+// uniformChooser is a simple random choice.
+type uniformChooser []Ranking
+
+func (c uniformChooser) Choose() Ranking { return c[rand.Intn(len(c))] }
+
+// NewRoundRobinChooser returns a Chooser which cycles through rs in order,
+// for exhaustively covering every emoji.
+func NewRoundRobinChooser(rs []Ranking) (Chooser, error) {
+	if len(rs) == 0 {
+		return nil, errNoRankings
+	}
+	return &roundRobinChooser{rs: rs}, nil
+}
+
+type roundRobinChooser struct {
+	rs   []Ranking
+	next atomic.Uint64
+}
+
+func (c *roundRobinChooser) Choose() Ranking {
+	return c.rs[(c.next.Add(1)-1)%uint64(len(c.rs))]
+}
@@ -0,0 +1,178 @@
+package fakefeeder
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestScaleWeights(t *testing.T) {
+	tests := []struct {
+		name    string
+		weights []float64
+	}{
+		{"equal", []float64{1, 1, 1}},
+		{"scores near max int64", []float64{math.MaxInt64, math.MaxInt64 - 1, math.MaxInt64 / 2}},
+		{"tiny beside max int64", []float64{math.MaxInt64, 1, 0}},
+		{"fractional", []float64{0.001, 0.002, 1e-300}},
+		{"one", []float64{42}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs := make([]Ranking, len(tt.weights))
+			total, err := checkWeights(rs, tt.weights)
+			if err != nil {
+				t.Fatal(err)
+			}
+			scaled := scaleWeights(tt.weights, total)
+			var sum uint64
+			for i, w := range scaled {
+				sum += uint64(w)
+				if tt.weights[i] > 0 && w == 0 {
+					t.Errorf("positive weight %v scaled to 0", tt.weights[i])
+				}
+				if tt.weights[i] == 0 && w != 0 {
+					t.Errorf("zero weight scaled to %d", w)
+				}
+			}
+			if sum > weightTotal+uint64(len(scaled)) {
+				t.Errorf("scaled weights sum to %d, more than %d", sum, weightTotal+len(scaled))
+			}
+		})
+	}
+}
+
+func TestScaleWeightsProportions(t *testing.T) {
+	weights := []float64{math.MaxInt64, math.MaxInt64 / 4}
+	total, _ := checkWeights(make([]Ranking, 2), weights)
+	scaled := scaleWeights(weights, total)
+	if ratio := float64(scaled[0]) / float64(scaled[1]); math.Abs(ratio-4) > 1e-6 {
+		t.Errorf("ratio of scaled weights = %v, want 4", ratio)
+	}
+}
+
+// The integer weights given to weightedrand must sum to no more than the
+// maximum int on 32-bit platforms, however large and many the scores.
+func TestScaleWeights32BitBound(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	weights := make([]float64, 100000)
+	for i := range weights {
+		weights[i] = float64(rng.Int63())
+	}
+	weights[0] = math.MaxInt64
+	total, err := checkWeights(make([]Ranking, len(weights)), weights)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sum uint64
+	for _, w := range scaleWeights(weights, total) {
+		sum += uint64(w)
+	}
+	if sum > math.MaxInt32 {
+		t.Errorf("scaled weights sum to %d, more than the 32-bit maximum int", sum)
+	}
+}
+
+func TestNewWeightedChooserLargeScores(t *testing.T) {
+	rs := []Ranking{
+		{ID: "A", Score: math.MaxInt64},
+		{ID: "B", Score: math.MaxInt64 - 1},
+		{ID: "C", Score: 0},
+	}
+	weights, err := Proportional()(rs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := NewWeightedChooser(rs, weights)
+	if err != nil {
+		t.Fatal(err)
+	}
+	counts := make(map[string]int)
+	for i := 0; i < 1000; i++ {
+		counts[c.Choose().ID]++
+	}
+	if counts["C"] != 0 {
+		t.Errorf("zero score chosen %d times", counts["C"])
+	}
+	if counts["A"] == 0 || counts["B"] == 0 {
+		t.Errorf("top scores not both chosen: %v", counts)
+	}
+}
+
+func TestNewWeightedChooserUniform(t *testing.T) {
+	rs := []Ranking{{ID: "A"}, {ID: "B"}}
+	for _, weights := range [][]float64{{0, 0}, {3, 3}} {
+		c, err := NewWeightedChooser(rs, weights)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := c.(uniformChooser); !ok {
+			t.Errorf("weights %v: got %T, want uniformChooser", weights, c)
+		}
+	}
+}
+
+func TestNewWeightedChooserInvalid(t *testing.T) {
+	rs := []Ranking{{ID: "A"}, {ID: "B"}}
+	for _, weights := range [][]float64{
+		{1},
+		{-1, 1},
+		{math.NaN(), 1},
+		{math.Inf(1), 1},
+		{math.MaxFloat64, math.MaxFloat64}, // sum overflows
+	} {
+		if _, err := NewWeightedChooser(rs, weights); err == nil {
+			t.Errorf("weights %v: want error", weights)
+		}
+	}
+	if _, err := NewWeightedChooser(nil, nil); err != errNoRankings {
+		t.Errorf("no rankings: err = %v, want %v", err, errNoRankings)
+	}
+}
+
+func TestWeightings(t *testing.T) {
+	rs := []Ranking{{ID: "A", Score: 100}, {ID: "B", Score: 1}, {ID: "C", Score: 10}}
+	tests := []struct {
+		name      string
+		weighting Weighting
+		want      []float64
+	}{
+		{"uniform", Uniform(), []float64{1, 1, 1}},
+		{"proportional", Proportional(), []float64{100, 1, 10}},
+		{"temperature 1", Temperature(1), []float64{1, 0.01, 0.1}},
+		{"temperature 0", Temperature(0), []float64{1, 1, 1}},
+		{"zipf", Zipf(1), []float64{1, 1.0 / 3, 1.0 / 2}},
+		{"log", LogScore(), []float64{math.Log1p(100), math.Log1p(1), math.Log1p(10)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.weighting(rs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := range tt.want {
+				if math.Abs(got[i]-tt.want[i]) > 1e-9 {
+					t.Errorf("weights = %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+
+	if _, err := Proportional()([]Ranking{{ID: "A", Score: -1}}); err == nil {
+		t.Error("negative score: want error")
+	}
+}
+
+func TestRoundRobinChooser(t *testing.T) {
+	rs := []Ranking{{ID: "A"}, {ID: "B"}, {ID: "C"}}
+	c, err := NewRoundRobinChooser(rs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 7; i++ {
+		if got, want := c.Choose().ID, rs[i%3].ID; got != want {
+			t.Errorf("choice %d = %s, want %s", i, got, want)
+		}
+	}
+}
@@ -0,0 +1,246 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	fakefeeder "github.com/emojitracker/emojitrack-fakefeeder"
+	"github.com/emojitracker/emojitrack-fakefeeder/rankings"
+)
+
+// envPrefix is prepended to the upper-cased flag name (with dashes replaced by
+// underscores) to form the environment variable overriding that setting, e.g.
+// FAKEFEEDER_LOG_FORMAT for -log-format.
+const envPrefix = "FAKEFEEDER_"
+
+// config holds all settings for the feeder. Settings are resolved in order of
+// increasing precedence from built-in defaults, the YAML config file,
+// environment variables, and finally command line flags.
+type config struct {
+	Target          string        `yaml:"target"`            // URI for redis target
+	Namespace       string        `yaml:"namespace"`         // prefix for all redis keys and channels
+	Rate            uint          `yaml:"rate"`              // updates per second
+	Workers         int           `yaml:"workers"`           // concurrent update workers
+	Weight          bool          `yaml:"weight"`            // weight emoji choice by historic score
+	Distribution    string        `yaml:"distribution"`      // score, uniform, zipf, temperature, log or round-robin
+	ZipfS           float64       `yaml:"zipf_s"`            // exponent for the zipf distribution
+	Temperature     float64       `yaml:"temperature"`       // exponent for the temperature distribution
+	Dynamics        string        `yaml:"dynamics"`          // static, rich-get-richer or flatten
+	Gain            float64       `yaml:"gain"`              // weight added per update for rich-get-richer
+	FlattenHalfLife time.Duration `yaml:"flatten_half_life"` // half-life of the drift to uniform for flatten
+
+	Seed     string `yaml:"seed"`     // "snapshot", or URL of a rankings API endpoint
+	Snapshot string `yaml:"snapshot"` // name of the bundled snapshot to seed from
+
+	Mirror struct {
+		URL      string        `yaml:"url"`      // rankings API to seed from and follow
+		Interval time.Duration `yaml:"interval"` // how often to poll the rankings API
+	} `yaml:"mirror"`
+
+	Synthesize struct {
+		Enabled bool    `yaml:"enabled"` // add emoji missing from the seed data
+		Max     int64   `yaml:"max"`     // highest synthesized score (0 for lowest seed score)
+		Alpha   float64 `yaml:"alpha"`   // long tail exponent
+		Seed    int64   `yaml:"seed"`    // random seed for score order (0 for random)
+	} `yaml:"synthesize"`
+
+	Filter struct {
+		Include    stringList `yaml:"include"`    // only these emoji IDs or chars
+		Exclude    stringList `yaml:"exclude"`    // never these emoji IDs or chars
+		Name       string     `yaml:"name"`       // regexp which emoji names must match
+		Categories stringList `yaml:"categories"` // only these categories or subcategories
+		Versions   stringList `yaml:"versions"`   // only emoji introduced in these emoji versions
+		Top        int        `yaml:"top"`        // only the top n emoji by score
+	} `yaml:"filter"`
+
+	HistoryDepth  int           `yaml:"history_depth"`  // recent tweets kept per emoji
+	HistoryWindow time.Duration `yaml:"history_window"` // backdate seeded tweets over this period
+
+	SeedChunkSize   int `yaml:"seed_chunk_size"`  // emoji per seeding transaction
+	SeedParallelism int `yaml:"seed_parallelism"` // concurrent seeding transactions
+
+	Scenario string `yaml:"scenario"` // path to scenario file to run instead of a constant rate
+
+	Listen string `yaml:"listen"` // address for the serve command's HTTP endpoints
+
+	Pool  fakefeeder.PoolOptions `yaml:"pool"`
+	Chaos fakefeeder.ChaosConfig `yaml:"chaos"`
+
+	Log struct {
+		Format  string `yaml:"format"`  // text or json
+		Level   string `yaml:"level"`   // debug, info, warn or error
+		Verbose bool   `yaml:"verbose"` // shorthand for debug level
+		Sample  uint64 `yaml:"sample"`  // log one in n updates when verbose
+	} `yaml:"log"`
+}
+
+func defaultConfig() config {
+	var c config
+	c.Target = "redis://localhost:6379"
+	c.Rate = 250
+	c.Workers = 1
+	c.Weight = true
+	c.Distribution = "score"
+	c.ZipfS = 1
+	c.Temperature = 1
+	c.Dynamics = fakefeeder.Static.String()
+	c.Gain = 1
+	c.FlattenHalfLife = fakefeeder.DefaultFlattenHalfLife
+	c.Seed = "snapshot"
+	c.Snapshot = rankings.DefaultSnapshot
+	c.Mirror.Interval = time.Minute
+	c.Synthesize.Alpha = 1
+	c.HistoryDepth = fakefeeder.DefaultHistoryDepth
+	c.SeedChunkSize = fakefeeder.DefaultSeedChunkSize
+	c.SeedParallelism = 1
+	c.Listen = ":8080"
+	c.Pool = fakefeeder.DefaultPoolOptions()
+	c.Log.Format = "text"
+	c.Log.Level = "info"
+	c.Log.Sample = 1
+	return c
+}
+
+// registerFlags binds flags for all settings in c to fs, using the current
+// values of c as defaults.
+func (c *config) registerFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.Target, "target", c.Target, "URI for redis target")
+	fs.StringVar(&c.Namespace, "namespace", c.Namespace, "prefix all redis keys and channels with this `namespace` and a colon, to share a redis between feeders")
+	fs.UintVar(&c.Rate, "rate", c.Rate, "number of updates per second to generate")
+	fs.IntVar(&c.Workers, "workers", c.Workers, "number of concurrent workers sharing the update rate")
+	fs.BoolVar(&c.Weight, "weight", c.Weight, "weight random update probability based on history (false is the same as -distribution uniform)")
+	fs.StringVar(&c.Distribution, "distribution", c.Distribution, "probability distribution of emoji choice: score (proportional), uniform, zipf (by rank), temperature (score^alpha), log (log score) or round-robin")
+	fs.Float64Var(&c.ZipfS, "zipf-s", c.ZipfS, "exponent `s` for -distribution zipf, weighting emoji by 1/rank^s")
+	fs.Float64Var(&c.Temperature, "temperature", c.Temperature, "exponent `alpha` for -distribution temperature, weighting emoji by score^alpha")
+	fs.StringVar(&c.Dynamics, "dynamics", c.Dynamics, "how emoji probabilities change over time: static, rich-get-richer (weights follow running scores) or flatten (drift toward uniform)")
+	fs.Float64Var(&c.Gain, "gain", c.Gain, "weight added to an emoji for each of its updates with -dynamics rich-get-richer")
+	fs.DurationVar(&c.FlattenHalfLife, "flatten-half-life", c.FlattenHalfLife, "time for probabilities to move halfway to uniform with -dynamics flatten")
+	fs.StringVar(&c.Seed, "seed", c.Seed, "seed data `source`: \"snapshot\" or a rankings API URL")
+	fs.StringVar(&c.Snapshot, "snapshot", c.Snapshot, "`name` of the bundled snapshot used by -seed snapshot, one of: "+strings.Join(rankings.Snapshots(), ", "))
+	fs.StringVar(&c.Mirror.URL, "mirror", c.Mirror.URL, "seed from this rankings API `URL`, and periodically follow its distribution")
+	fs.DurationVar(&c.Mirror.Interval, "mirror-interval", c.Mirror.Interval, "how often to poll the rankings API when mirroring")
+	fs.BoolVar(&c.Synthesize.Enabled, "synthesize", c.Synthesize.Enabled, "add synthesized scores for all Unicode emoji missing from the seed data")
+	fs.Int64Var(&c.Synthesize.Max, "synth-max", c.Synthesize.Max, "highest synthesized score (0 for the lowest seed data score)")
+	fs.Float64Var(&c.Synthesize.Alpha, "synth-alpha", c.Synthesize.Alpha, "long tail exponent for synthesized scores, higher falls off faster")
+	fs.Int64Var(&c.Synthesize.Seed, "synth-seed", c.Synthesize.Seed, "random seed for the order of synthesized scores (0 for random)")
+	fs.Var(&c.Filter.Include, "include", "only feed these comma separated emoji `IDs` (or chars)")
+	fs.Var(&c.Filter.Exclude, "exclude", "never feed these comma separated emoji `IDs` (or chars)")
+	fs.StringVar(&c.Filter.Name, "name-match", c.Filter.Name, "only feed emoji with names matching this `regexp`")
+	fs.Var(&c.Filter.Categories, "category", "only feed emoji in these comma separated Unicode `categories` or subcategories")
+	fs.Var(&c.Filter.Versions, "emoji-version", "only feed emoji introduced in these comma separated emoji `versions`")
+	fs.IntVar(&c.Filter.Top, "top", c.Filter.Top, "only feed the top `n` emoji by score")
+	fs.IntVar(&c.HistoryDepth, "history-depth", c.HistoryDepth, "number of recent tweets kept for each emoji")
+	fs.DurationVar(&c.HistoryWindow, "history-window", c.HistoryWindow, "backdate the initially seeded tweets randomly over this period")
+	fs.IntVar(&c.SeedChunkSize, "seed-chunk-size", c.SeedChunkSize, "maximum number of emoji seeded in a single redis transaction")
+	fs.IntVar(&c.SeedParallelism, "seed-parallelism", c.SeedParallelism, "number of seeding transactions to run concurrently")
+	fs.StringVar(&c.Scenario, "scenario", c.Scenario, "run the scenario `file` then exit, instead of a constant rate forever")
+	fs.StringVar(&c.Listen, "listen", c.Listen, "`address` to serve HTTP on, for the serve command")
+	fs.IntVar(&c.Pool.MaxIdle, "pool-max-idle", c.Pool.MaxIdle, "maximum number of idle redis connections in the pool")
+	fs.IntVar(&c.Pool.MaxActive, "pool-max-active", c.Pool.MaxActive, "maximum number of redis connections in the pool (0 for unlimited)")
+	fs.DurationVar(&c.Pool.IdleTimeout, "pool-idle-timeout", c.Pool.IdleTimeout, "close redis connections idle for longer than this")
+	fs.DurationVar(&c.Pool.MaxConnLifetime, "pool-max-conn-lifetime", c.Pool.MaxConnLifetime, "close redis connections older than this (0 for no limit)")
+	fs.BoolVar(&c.Pool.Wait, "pool-wait", c.Pool.Wait, "wait for a free connection when -pool-max-active is reached, rather than failing")
+	fs.DurationVar(&c.Pool.ConnectTimeout, "redis-connect-timeout", c.Pool.ConnectTimeout, "timeout for connecting to redis")
+	fs.DurationVar(&c.Pool.ReadTimeout, "redis-read-timeout", c.Pool.ReadTimeout, "timeout for reading a reply from redis")
+	fs.DurationVar(&c.Pool.WriteTimeout, "redis-write-timeout", c.Pool.WriteTimeout, "timeout for writing a command to redis")
+	fs.DurationVar(&c.Chaos.Latency, "chaos-latency", c.Chaos.Latency, "add fixed latency to every update")
+	fs.DurationVar(&c.Chaos.Jitter, "chaos-jitter", c.Chaos.Jitter, "add up to this much random latency to every update")
+	fs.Float64Var(&c.Chaos.DropRate, "chaos-drop", c.Chaos.DropRate, "probability of silently dropping an update")
+	fs.Float64Var(&c.Chaos.DuplicateRate, "chaos-duplicate", c.Chaos.DuplicateRate, "probability of sending an update twice")
+	fs.Float64Var(&c.Chaos.PublishOnlyRate, "chaos-publish-only", c.Chaos.PublishOnlyRate, "probability of publishing an update without incrementing its score")
+	fs.Float64Var(&c.Chaos.IncrementOnlyRate, "chaos-increment-only", c.Chaos.IncrementOnlyRate, "probability of incrementing a score without publishing the update")
+	fs.DurationVar(&c.Chaos.DisconnectEvery, "chaos-disconnect-every", c.Chaos.DisconnectEvery, "force-close all redis connections on this interval")
+	fs.StringVar(&c.Log.Format, "log-format", c.Log.Format, "log output format (text or json)")
+	fs.StringVar(&c.Log.Level, "log-level", c.Log.Level, "minimum log level (debug, info, warn or error)")
+	fs.BoolVar(&c.Log.Verbose, "v", c.Log.Verbose, "verbose log all feeder updates (same as -log-level=debug)")
+	fs.Uint64Var(&c.Log.Sample, "v-sample", c.Log.Sample, "when verbose, only log one in every `n` feeder updates")
+}
+
+// loadConfig parses args with fs, and resolves the final config from defaults,
+// the config file given by -config (or $FAKEFEEDER_CONFIG), the environment,
+// and the flags explicitly present in args.
+func loadConfig(fs *flag.FlagSet, args []string) (*config, error) {
+	cfg := defaultConfig()
+	cfg.registerFlags(fs)
+	path := fs.String("config", os.Getenv(envPrefix+"CONFIG"), "path to YAML config `file`")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	// remember the explicitly set flags, since applying the config file will
+	// clobber the values they were bound to
+	explicit := make(map[string]string)
+	fs.Visit(func(f *flag.Flag) {
+		explicit[f.Name] = f.Value.String()
+	})
+
+	cfg = defaultConfig()
+	if *path != "" {
+		if err := cfg.readFile(*path); err != nil {
+			return nil, err
+		}
+	}
+
+	var err error
+	fs.VisitAll(func(f *flag.Flag) {
+		if err != nil || f.Name == "config" {
+			return
+		}
+		key := envPrefix + strings.ToUpper(strings.ReplaceAll(f.Name, "-", "_"))
+		if v, ok := os.LookupEnv(key); ok {
+			if serr := f.Value.Set(v); serr != nil {
+				err = fmt.Errorf("invalid value %q for $%s: %w", v, key, serr)
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for name, v := range explicit {
+		if err := fs.Set(name, v); err != nil {
+			return nil, err
+		}
+	}
+	return &cfg, nil
+}
+
+// readFile overlays the settings present in the YAML file at path onto c.
+func (c *config) readFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("could not open config file: %w", err)
+	}
+	defer f.Close()
+
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("could not parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// stringList is a flag.Value for a comma separated list of strings.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(v string) error {
+	*l = nil
+	for _, s := range strings.Split(v, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			*l = append(*l, s)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeConfig writes a config file holding yaml to a temporary directory, and
+// returns its path.
+func writeConfig(t *testing.T, yaml string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "fakefeeder.yml")
+	if err := os.WriteFile(path, []byte(yaml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// testLoadConfig calls loadConfig with a new FlagSet, as newEnv would.
+func testLoadConfig(args []string) (*config, error) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return loadConfig(fs, args)
+}
+
+func TestLoadConfigPrecedence(t *testing.T) {
+	file := writeConfig(t, `
+rate: 10
+workers: 2
+target: redis://file:6379
+history_window: 90s
+mirror:
+  interval: 5m
+filter:
+  include: [1F602, 2764]
+  exclude:
+    - 1F525
+`)
+
+	for _, tc := range []struct {
+		name string
+		env  map[string]string
+		args []string
+		want func(*config)
+	}{
+		{
+			name: "defaults",
+			want: func(c *config) {},
+		},
+		{
+			name: "file",
+			args: []string{"-config", file},
+			want: func(c *config) {
+				c.Rate = 10
+				c.Workers = 2
+				c.Target = "redis://file:6379"
+				c.HistoryWindow = 90 * time.Second
+				c.Mirror.Interval = 5 * time.Minute
+				c.Filter.Include = stringList{"1F602", "2764"}
+				c.Filter.Exclude = stringList{"1F525"}
+			},
+		},
+		{
+			name: "config path from env",
+			env:  map[string]string{"FAKEFEEDER_CONFIG": file},
+			want: func(c *config) {
+				c.Rate = 10
+				c.Workers = 2
+				c.Target = "redis://file:6379"
+				c.HistoryWindow = 90 * time.Second
+				c.Mirror.Interval = 5 * time.Minute
+				c.Filter.Include = stringList{"1F602", "2764"}
+				c.Filter.Exclude = stringList{"1F525"}
+			},
+		},
+		{
+			name: "env over file",
+			env: map[string]string{
+				"FAKEFEEDER_RATE":            "20",
+				"FAKEFEEDER_HISTORY_WINDOW":  "1h30m",
+				"FAKEFEEDER_MIRROR_INTERVAL": "10s",
+				"FAKEFEEDER_INCLUDE":         " 1F44D , ,1F600",
+				"FAKEFEEDER_LOG_FORMAT":      "json",
+			},
+			args: []string{"-config", file},
+			want: func(c *config) {
+				c.Rate = 20
+				c.Workers = 2
+				c.Target = "redis://file:6379"
+				c.HistoryWindow = 90 * time.Minute
+				c.Mirror.Interval = 10 * time.Second
+				c.Filter.Include = stringList{"1F44D", "1F600"}
+				c.Filter.Exclude = stringList{"1F525"}
+				c.Log.Format = "json"
+			},
+		},
+		{
+			name: "flags over env and file",
+			env: map[string]string{
+				"FAKEFEEDER_RATE":    "20",
+				"FAKEFEEDER_WORKERS": "3",
+			},
+			args: []string{"-config", file, "-rate", "30", "-history-window", "2s", "-exclude", "", "-v"},
+			want: func(c *config) {
+				c.Rate = 30
+				c.Workers = 3
+				c.Target = "redis://file:6379"
+				c.HistoryWindow = 2 * time.Second
+				c.Mirror.Interval = 5 * time.Minute
+				c.Filter.Include = stringList{"1F602", "2764"}
+				c.Log.Verbose = true
+			},
+		},
+		{
+			// a flag explicitly set to its default still wins
+			name: "flag set to default",
+			env:  map[string]string{"FAKEFEEDER_RATE": "20"},
+			args: []string{"-config", file, "-rate", "250"},
+			want: func(c *config) {
+				c.Workers = 2
+				c.Target = "redis://file:6379"
+				c.HistoryWindow = 90 * time.Second
+				c.Mirror.Interval = 5 * time.Minute
+				c.Filter.Include = stringList{"1F602", "2764"}
+				c.Filter.Exclude = stringList{"1F525"}
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+			got, err := testLoadConfig(tc.args)
+			if err != nil {
+				t.Fatalf("loadConfig(%q) error = %v", tc.args, err)
+			}
+			want := defaultConfig()
+			tc.want(&want)
+			if !reflect.DeepEqual(*got, want) {
+				t.Errorf("loadConfig(%q) =\n%+v\nwant\n%+v", tc.args, *got, want)
+			}
+		})
+	}
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		yaml string
+		env  map[string]string
+		args []string
+		want string
+	}{
+		{
+			name: "unknown key",
+			yaml: "rate: 10\nrat: 20\n",
+			want: "field rat not found",
+		},
+		{
+			name: "unknown nested key",
+			yaml: "mirror:\n  url: http://example.com\n  every: 1m\n",
+			want: "field every not found",
+		},
+		{
+			name: "invalid duration",
+			yaml: "history_window: soon\n",
+			want: "could not parse config file",
+		},
+		{
+			name: "invalid env",
+			env:  map[string]string{"FAKEFEEDER_RATE": "fast"},
+			want: "$FAKEFEEDER_RATE",
+		},
+		{
+			name: "invalid flag",
+			args: []string{"-workers", "many"},
+			want: "invalid value",
+		},
+		{
+			name: "missing file",
+			args: []string{"-config", "/nonexistent/fakefeeder.yml"},
+			want: "could not open config file",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+			args := tc.args
+			if tc.yaml != "" {
+				args = append([]string{"-config", writeConfig(t, tc.yaml)}, args...)
+			}
+			_, err := testLoadConfig(args)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("loadConfig(%q) error = %v, want containing %q", args, err, tc.want)
+			}
+		})
+	}
+}
+
+// Every setting in the example config file is a known key, so it loads
+// without changing the defaults it documents.
+func TestExampleConfig(t *testing.T) {
+	got, err := testLoadConfig([]string{"-config", "../../fakefeeder.example.yml"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// empty lists in the file are the same as none
+	for _, l := range []*stringList{&got.Filter.Include, &got.Filter.Exclude, &got.Filter.Categories, &got.Filter.Versions} {
+		if len(*l) == 0 {
+			*l = nil
+		}
+	}
+	if want := defaultConfig(); !reflect.DeepEqual(*got, want) {
+		t.Errorf("example config =\n%+v\nwant the defaults\n%+v", *got, want)
+	}
+}
+
+func TestStringList(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		want stringList
+		out  string
+	}{
+		{"", nil, ""},
+		{"1F602", stringList{"1F602"}, "1F602"},
+		{"1F602,2764", stringList{"1F602", "2764"}, "1F602,2764"},
+		{" 1F602 , , 2764 ,", stringList{"1F602", "2764"}, "1F602,2764"},
+	} {
+		l := stringList{"old"}
+		if err := l.Set(tc.in); err != nil {
+			t.Fatalf("Set(%q) error = %v", tc.in, err)
+		}
+		if !reflect.DeepEqual(l, tc.want) {
+			t.Errorf("Set(%q) = %q, want %q", tc.in, l, tc.want)
+		}
+		if got := l.String(); got != tc.out {
+			t.Errorf("Set(%q).String() = %q, want %q", tc.in, got, tc.out)
+		}
+	}
+}
@@ -2,58 +2,416 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
-	"log"
+	"fmt"
+	"io"
+	"log/slog"
 	"math/rand"
 	"os"
+	"path/filepath"
+	"regexp"
 	"strings"
+	"text/tabwriter"
 	"time"
 
+	"github.com/gomodule/redigo/redis"
+
 	fakefeeder "github.com/emojitracker/emojitrack-fakefeeder"
 	rankings "github.com/emojitracker/emojitrack-fakefeeder/rankings"
 )
 
-var (
-	targetURL = flag.String("target", "redis://localhost:6379", "URI for redis target")
-	rate      = flag.Uint("rate", 250, "number of updates per second to generate")
-	weighted  = flag.Bool("weight", true, "weight random update probability based on history")
-	verbose   = flag.Bool("v", false, "verbose log all feeder updates")
-)
+// command is a fakefeeder subcommand, run with the arguments following its
+// name.
+type command struct {
+	name    string
+	summary string
+	run     func(args []string)
+}
+
+// commands lists every subcommand, in the order shown by help. It is filled
+// in by init, since the commands refer back to it for their own usage.
+var commands []command
+
+func init() {
+	commands = []command{
+		{"run", "seed redis, then send random updates until interrupted (the default)", cmdRun},
+		{"seed", "seed redis with the initial scores and tweets, then exit", cmdSeed},
+		{"reset", "clear all scores and tweets from redis and seed it again, then exit", cmdReset},
+		{"verify", "check redis holds valid data for every seeded emoji, then exit", cmdVerify},
+		{"replay", "send the updates listed in a file, then exit", cmdReplay},
+		{"serve", "serve HTTP endpoints for sending updates and resetting on demand", cmdServe},
+		{"snapshot", "print the seed data, or list the bundled snapshots", cmdSnapshot},
+		{"watch", "subscribe to the published streams and check every message", cmdWatch},
+	}
+}
 
 func main() {
-	flag.Parse()
-	logger := log.New(os.Stderr, "", log.LstdFlags)
-	logger.Printf("Starting up. Target: %v (rate: %d/sec.)\n", *targetURL, *rate)
+	// don't forget to seed random!
+	rand.Seed(time.Now().UnixNano())
+
+	// with no command, run (so flags alone work as they always have)
+	args := os.Args[1:]
+	if len(args) == 0 || (strings.HasPrefix(args[0], "-") && !isHelp(args[0])) {
+		cmdRun(args)
+		return
+	}
+	if isHelp(args[0]) || args[0] == "help" {
+		if len(args) > 1 {
+			if c, ok := lookupCommand(args[1]); ok {
+				c.run([]string{"-h"})
+			}
+		}
+		usage(os.Stdout)
+		return
+	}
+	c, ok := lookupCommand(args[0])
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", args[0])
+		usage(os.Stderr)
+		os.Exit(2)
+	}
+	c.run(args[1:])
+}
+
+// isHelp reports whether arg is one of the flags requesting help.
+func isHelp(arg string) bool {
+	switch arg {
+	case "-h", "-help", "--help":
+		return true
+	}
+	return false
+}
+
+// lookupCommand returns the command with the given name.
+func lookupCommand(name string) (command, bool) {
+	for _, c := range commands {
+		if c.name == name {
+			return c, true
+		}
+	}
+	return command{}, false
+}
+
+// usage prints the list of commands to w.
+func usage(w io.Writer) {
+	fmt.Fprintf(w, "Usage: %s [command] [flags]\n\nCommands:\n", programName())
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, c := range commands {
+		fmt.Fprintf(tw, "  %s\t%s\n", c.name, c.summary)
+	}
+	tw.Flush()
+	fmt.Fprintf(w, "\nRun '%s help <command>' for the flags of a command.\n", programName())
+}
+
+func programName() string {
+	return filepath.Base(os.Args[0])
+}
+
+// newFlagSet returns the FlagSet for the named command, whose usage describes
+// the positional arguments given by synopsis (if any).
+func newFlagSet(name, synopsis string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	fs.Usage = func() {
+		c, _ := lookupCommand(name)
+		w := fs.Output()
+		fmt.Fprintf(w, "Usage: %s %s [flags]", programName(), name)
+		if synopsis != "" {
+			fmt.Fprint(w, " "+synopsis)
+		}
+		fmt.Fprint(w, "\n\n")
+		fmt.Fprintf(w, "%s%s.\n\nFlags:\n", strings.ToUpper(c.summary[:1]), c.summary[1:])
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// env is the state shared by the commands: the resolved config and logger,
+// along with the seed data, redis connection and feeder as each is set up.
+type env struct {
+	cfg    *config
+	logger *slog.Logger
+
+	source      string               // where the seed data was loaded from
+	seed        []fakefeeder.Ranking // prepared seed data
+	synthesized map[string]bool      // IDs of the emoji in seed added by -synthesize
+	randomSynth bool                 // whether the synthesized scores were given no -synth-seed
+	pool        *redis.Pool
+	sink        *fakefeeder.RedisSink
+	weighting   fakefeeder.Weighting // nil unless choosing by a Weighting
+	feeder      *fakefeeder.Feeder
+}
+
+// newEnv parses args with fs (holding any flags of the command itself), and
+// resolves the config and logger, exiting if they are invalid.
+func newEnv(fs *flag.FlagSet, args []string) *env {
+	cfg, err := loadConfig(fs, args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	logger, err := newLogger(cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	return &env{cfg: cfg, logger: logger}
+}
+
+// loadSeed loads the seed data (when mirroring, from the mirrored endpoint),
+// and prepares it as configured.
+func (e *env) loadSeed() {
+	e.source = e.cfg.Seed
+	if e.cfg.Mirror.URL != "" {
+		e.source = e.cfg.Mirror.URL
+	}
+	seed, err := seedData(e.source, e.cfg.Snapshot)
+	if err != nil {
+		fatal(e.logger, "could not load seed data", err)
+	}
+	if e.cfg.Synthesize.Enabled && e.cfg.Synthesize.Seed == 0 {
+		// pick a random seed once, so that the synthesized scores stay the
+		// same whenever the seed data is prepared again (e.g. when mirroring)
+		e.randomSynth = true
+		for e.cfg.Synthesize.Seed == 0 {
+			e.cfg.Synthesize.Seed = rand.Int63()
+		}
+		e.logger.Debug("picked random seed for synthesized scores", "synth_seed", e.cfg.Synthesize.Seed)
+	}
+	e.seed, err = e.prepare(seed)
+	if err != nil {
+		fatal(e.logger, "could not filter seed data", err)
+	}
+
+	e.synthesized = make(map[string]bool)
+	if e.cfg.Synthesize.Enabled {
+		loaded := make(map[string]bool, len(seed))
+		for _, r := range seed {
+			loaded[r.ID] = true
+		}
+		for _, r := range e.seed {
+			if !loaded[r.ID] {
+				e.synthesized[r.ID] = true
+			}
+		}
+	}
+}
+
+// prepare is prepareSeed for the config of e.
+func (e *env) prepare(rs []fakefeeder.Ranking) ([]fakefeeder.Ranking, error) {
+	return prepareSeed(e.cfg, rs)
+}
 
+// connect sets up the redis pool and sink for the target.
+func (e *env) connect() {
 	// paranoid safety check: refuse to go anywhere near a production DB
-	if strings.Contains(*targetURL, "rediscloud") {
-		logger.Fatal("Are you certain you aren't trying to hit a prod db?")
+	if strings.Contains(e.cfg.Target, "rediscloud") {
+		fatal(e.logger, "are you certain you aren't trying to hit a prod db?", nil)
 	}
 
 	// otherwise, set up the redis pool
-	pool, err := targetPool(*targetURL)
+	var err error
+	e.pool, err = fakefeeder.NewPool(e.cfg.Target, e.cfg.Pool)
 	if err != nil {
-		logger.Fatal(err)
+		fatal(e.logger, "could not set up redis pool", err)
 	}
+	e.sink = fakefeeder.NewRedisSink(e.pool)
+	e.sink.Namespace = e.cfg.Namespace
+}
 
-	// don't forget to seed random!
-	rand.Seed(time.Now().UnixNano())
-
-	// set up feeder with initial state in redis
-	logger.Println("Setting up initial feeder state...")
-	feeder, err := fakefeeder.NewFeeder(pool, rankings.Snapshot(), *weighted)
+// startFeeder connects to redis and sets up the feeder for the seed data,
+// seeding redis with its initial state unless skipSeed is set.
+func (e *env) startFeeder(skipSeed bool) {
+	e.connect()
+	var sink fakefeeder.Sink = e.sink
+	if e.cfg.Chaos != (fakefeeder.ChaosConfig{}) {
+		e.logger.Warn("injecting faults into updates", "chaos", e.cfg.Chaos)
+		var err error
+		sink, err = fakefeeder.NewChaosSink(e.sink, e.cfg.Chaos)
+		if err != nil {
+			fatal(e.logger, "could not set up fault injection", err)
+		}
+	}
+	weighting, chooser, err := distribution(e.cfg)
+	if err != nil {
+		fatal(e.logger, "invalid distribution", err)
+	}
+	dynamics, err := fakefeeder.ParseDynamics(e.cfg.Dynamics)
 	if err != nil {
-		logger.Fatal(err)
+		fatal(e.logger, "invalid dynamics", err)
+	}
+	if skipSeed {
+		e.logger.Info("setting up feeder on existing state", "seed", e.source, "emoji", len(e.seed))
+	} else {
+		e.logger.Info("setting up initial feeder state", "seed", e.source, "emoji", len(e.seed))
+	}
+	opts := fakefeeder.Options{
+		Weighted:        e.cfg.Weight,
+		Weighting:       weighting,
+		Chooser:         chooser,
+		Dynamics:        dynamics,
+		Gain:            e.cfg.Gain,
+		FlattenHalfLife: e.cfg.FlattenHalfLife,
+		HistoryDepth:    e.cfg.HistoryDepth,
+		HistoryWindow:   e.cfg.HistoryWindow,
+		SeedChunkSize:   e.cfg.SeedChunkSize,
+		SeedParallelism: e.cfg.SeedParallelism,
+		SeedProgress:    seedProgressLogger(e.logger),
+		SkipSeed:        skipSeed,
+	}
+	// redis may not be accepting connections yet (e.g. when started alongside
+	// it by docker-compose), so keep trying while the failure is transient
+	var backoff fakefeeder.Backoff
+	for {
+		e.feeder, err = fakefeeder.NewFeeder(sink, e.seed, opts)
+		if err == nil || !fakefeeder.IsTransient(err) {
+			break
+		}
+		wait := backoff.Next()
+		e.logger.Warn("could not reach sink, retrying", "error", err, "error_class", fakefeeder.ErrorClass(err), "retry_in", wait)
+		time.Sleep(wait)
+	}
+	if err != nil {
+		fatal(e.logger, "could not set up feeder", err)
+	}
+	e.feeder.Logger = e.logger
+	e.weighting = weighting
+}
+
+// sendUpdates sends random updates at the configured rate until ctx is done,
+// logging any failures.
+func (e *env) sendUpdates(ctx context.Context) {
+	period := time.Second / time.Duration(e.cfg.Rate)
+	e.logger.Info("sending fake updates", "period", period, "rate", e.cfg.Rate, "workers", e.cfg.Workers)
+	run := e.feeder.StartWorkers(ctx, period, e.cfg.Workers)
+	for err := range run.Errors() {
+		e.logger.Error("update failed", "error", err, "error_class", fakefeeder.ErrorClass(err))
+	}
+}
+
+// lookupEmoji finds the ranking in rs matching an emoji ID or char.
+func lookupEmoji(rs []fakefeeder.Ranking, emoji string) (fakefeeder.Ranking, bool) {
+	for _, r := range rs {
+		if r.ID == emoji || r.Char == emoji {
+			return r, true
+		}
+	}
+	return fakefeeder.Ranking{}, false
+}
+
+// seedData returns the rankings to seed the feeder with from source, which is
+// either "snapshot" for the named bundled snapshot, or the URL of a rankings
+// API.
+func seedData(source, snapshot string) ([]fakefeeder.Ranking, error) {
+	if source == "snapshot" {
+		return rankings.NamedSnapshot(snapshot)
+	}
+	return rankings.Live(source)
+}
+
+// distribution returns the Weighting or Chooser options for the distribution
+// configured by cfg.
+func distribution(cfg *config) (fakefeeder.Weighting, func([]fakefeeder.Ranking) (fakefeeder.Chooser, error), error) {
+	name := cfg.Distribution
+	if !cfg.Weight && name == "score" {
+		name = "uniform"
+	}
+	switch name {
+	case "score":
+		return fakefeeder.Proportional(), nil, nil
+	case "uniform":
+		return fakefeeder.Uniform(), nil, nil
+	case "zipf":
+		return fakefeeder.Zipf(cfg.ZipfS), nil, nil
+	case "temperature":
+		return fakefeeder.Temperature(cfg.Temperature), nil, nil
+	case "log":
+		return fakefeeder.LogScore(), nil, nil
+	case "round-robin":
+		return nil, fakefeeder.NewRoundRobinChooser, nil
 	}
-	if *verbose {
-		feeder.VerboseLogger = logger
+	return nil, nil, fmt.Errorf("unknown distribution %q", cfg.Distribution)
+}
+
+// prepareSeed adds synthesized emoji and metadata to seed, and applies any
+// filters, all as configured by cfg.
+func prepareSeed(cfg *config, seed []fakefeeder.Ranking) ([]fakefeeder.Ranking, error) {
+	if cfg.Synthesize.Enabled {
+		seed = rankings.Synthesize(seed, rankings.LongTail{
+			Max:   cfg.Synthesize.Max,
+			Alpha: cfg.Synthesize.Alpha,
+			Seed:  cfg.Synthesize.Seed,
+		})
 	}
+	return filterSeed(cfg, rankings.WithMetadata(seed))
+}
 
-	// start feeding redis random updates
-	period := time.Second / time.Duration(*rate)
-	logger.Printf("Sending fake updates every %v (%v/sec)", period, *rate)
-	errChan := feeder.Start(context.Background(), period)
-	for err := range errChan {
-		logger.Println("ERROR:", err)
+// filterSeed returns the subset of seed selected by the filter settings in cfg.
+func filterSeed(cfg *config, seed []fakefeeder.Ranking) ([]fakefeeder.Ranking, error) {
+	filter := rankings.Filter{
+		Include:    cfg.Filter.Include,
+		Exclude:    cfg.Filter.Exclude,
+		Categories: cfg.Filter.Categories,
+		Versions:   cfg.Filter.Versions,
+		Top:        cfg.Filter.Top,
+	}
+	if cfg.Filter.Name != "" {
+		re, err := regexp.Compile(cfg.Filter.Name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid name filter: %w", err)
+		}
+		filter.Name = re
+	}
+	filtered := filter.Apply(seed)
+	if len(filtered) == 0 {
+		return nil, errors.New("no emoji match the filter")
+	}
+	return filtered, nil
+}
+
+// seedProgressLogger returns a callback for fakefeeder.Options.SeedProgress
+// which logs seeding progress, at most once per second and on completion.
+func seedProgressLogger(logger *slog.Logger) func(fakefeeder.SeedProgress) {
+	var last time.Time
+	return func(p fakefeeder.SeedProgress) {
+		if p.Done < p.Total && time.Since(last) < time.Second {
+			return
+		}
+		last = time.Now()
+		logger.Info("seeding", "what", p.What, "done", p.Done, "total", p.Total)
+	}
+}
+
+// newLogger builds the structured logger configured by cfg.
+func newLogger(cfg *config) (*slog.Logger, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(cfg.Log.Level)); err != nil {
+		return nil, fmt.Errorf("invalid log level: %w", err)
+	}
+	if cfg.Log.Verbose {
+		level = slog.LevelDebug
+	}
+
+	opts := &slog.HandlerOptions{Level: level}
+	var h slog.Handler
+	switch cfg.Log.Format {
+	case "text":
+		h = slog.NewTextHandler(os.Stderr, opts)
+	case "json":
+		h = slog.NewJSONHandler(os.Stderr, opts)
+	default:
+		return nil, fmt.Errorf("invalid log format: %q", cfg.Log.Format)
+	}
+	h = fakefeeder.NewSampleHandler(h, slog.LevelDebug, cfg.Log.Sample)
+	return slog.New(h), nil
+}
+
+// fatal logs msg and err at error level, then exits.
+func fatal(logger *slog.Logger, msg string, err error) {
+	if err != nil {
+		logger.Error(msg, "error", err)
+	} else {
+		logger.Error(msg)
 	}
+	os.Exit(1)
 }
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"time"
+
+	fakefeeder "github.com/emojitracker/emojitrack-fakefeeder"
+	"github.com/emojitracker/emojitrack-fakefeeder/rankings"
+)
+
+// mirror periodically polls the rankings endpoint url, switching the emoji
+// chosen by feeder to follow its distribution, until ctx is cancelled. Each
+// refresh passes through prepare (synthesizing and filtering as configured).
+//
+// Failed refreshes are logged and otherwise ignored, keeping the previous
+// distribution until the next attempt.
+func mirror(ctx context.Context, feeder *fakefeeder.Feeder, url string, interval time.Duration,
+	current []fakefeeder.Ranking, prepare func([]fakefeeder.Ranking) ([]fakefeeder.Ranking, error), logger *slog.Logger) {
+	var client rankings.Client
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		rs, err := client.Rankings(ctx, url)
+		if err == nil {
+			rs, err = prepare(rs)
+		}
+		if err == nil {
+			err = feeder.SetRankings(rs)
+		}
+		if err != nil {
+			logger.Warn("could not refresh mirrored rankings", "url", url, "error", err)
+			continue
+		}
+		logger.Info("refreshed mirrored rankings", "url", url, "emoji", len(rs), "drift", drift(current, rs))
+		current = rs
+	}
+}
+
+// drift returns the total variation distance between the score distributions
+// of two sets of rankings: 0 when they are proportionally identical, up to 1
+// when they have no emoji in common.
+func drift(a, b []fakefeeder.Ranking) float64 {
+	share := func(rs []fakefeeder.Ranking) map[string]float64 {
+		var total float64
+		for _, r := range rs {
+			total += float64(r.Score)
+		}
+		m := make(map[string]float64, len(rs))
+		for _, r := range rs {
+			if total > 0 {
+				m[r.ID] = float64(r.Score) / total
+			}
+		}
+		return m
+	}
+	p, q := share(a), share(b)
+	var d float64
+	for id, v := range p {
+		d += math.Abs(v - q[id])
+	}
+	for id, v := range q {
+		if _, ok := p[id]; !ok {
+			d += v
+		}
+	}
+	return d / 2
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	fakefeeder "github.com/emojitracker/emojitrack-fakefeeder"
+)
+
+// cmdReplay runs the replay command: seed redis (unless -no-seed), then send
+// exactly the updates listed in a replay file, and exit.
+//
+// A replay file lists one update per line, as an emoji ID or char, optionally
+// preceded by the offset from the start of the replay to send it at:
+//
+//	# lines starting with "# " are comments
+//	1F525
+//	😂
+//	1.5s 1F525
+//	2s 2764-FE0F
+//
+// Updates without an offset are sent one -rate period after the previous one.
+// Offsets must not go backwards.
+func cmdReplay(args []string) {
+	fs := newFlagSet("replay", "file")
+	noSeed := fs.Bool("no-seed", false, "send updates on top of the data already in redis (e.g. from the seed command), rather than seeding it first")
+	speed := fs.Float64("speed", 1, "replay speed multiplier, e.g. 2 for twice as fast")
+	e := newEnv(fs, args)
+	if fs.NArg() != 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	if !(*speed > 0) {
+		fatal(e.logger, "speed must be positive", nil)
+	}
+	e.loadSeed()
+
+	// read the file before seeding, so a bad file fails fast
+	var gap time.Duration
+	if e.cfg.Rate > 0 {
+		gap = time.Second / time.Duration(e.cfg.Rate)
+	}
+	events, err := readReplayFile(fs.Arg(0), e.seed, gap)
+	if err != nil {
+		fatal(e.logger, "could not load replay file", err)
+	}
+	e.startFeeder(*noSeed)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	e.logger.Info("replaying updates", "file", fs.Arg(0), "updates", len(events), "speed", *speed)
+	start := time.Now()
+	var sent, failed int
+	for _, ev := range events {
+		if sleep(ctx, time.Until(start.Add(time.Duration(float64(ev.at) / *speed)))) != nil {
+			break
+		}
+		if err := e.feeder.UpdateEmoji(ev.emoji); err != nil {
+			failed++
+			e.logger.Error("update failed", "emoji_id", ev.emoji.ID, "error", err, "error_class", fakefeeder.ErrorClass(err))
+			continue
+		}
+		sent++
+	}
+	e.logger.Info("replay complete", "sent", sent, "failed", failed, "skipped", len(events)-sent-failed, "elapsed", time.Since(start))
+	if sent < len(events) {
+		os.Exit(1)
+	}
+}
+
+// replayEvent is a single update from a replay file.
+type replayEvent struct {
+	at    time.Duration // offset from the start of the replay
+	emoji fakefeeder.Ranking
+}
+
+// readReplayFile reads the replay file at path, or stdin if "-", see readReplay.
+func readReplayFile(path string, seed []fakefeeder.Ranking, gap time.Duration) ([]replayEvent, error) {
+	if path == "-" {
+		return readReplay(os.Stdin, seed, gap)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return readReplay(f, seed, gap)
+}
+
+// readReplay parses a replay file of updates for emoji in seed, spacing
+// updates without an offset gap after the previous one.
+func readReplay(r io.Reader, seed []fakefeeder.Ranking, gap time.Duration) ([]replayEvent, error) {
+	var events []replayEvent
+	var at time.Duration
+	s := bufio.NewScanner(r)
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || line == "#" || strings.HasPrefix(line, "# ") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		next := at + gap
+		if len(events) == 0 {
+			next = 0
+		}
+		switch len(fields) {
+		case 1:
+		case 2:
+			d, err := time.ParseDuration(fields[0])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid offset: %w", n, err)
+			}
+			if d < at {
+				return nil, fmt.Errorf("line %d: offset %v is before the previous update at %v", n, d, at)
+			}
+			next = d
+		default:
+			return nil, fmt.Errorf("line %d: want an emoji, optionally preceded by an offset", n)
+		}
+
+		emoji, ok := lookupEmoji(seed, fields[len(fields)-1])
+		if !ok {
+			return nil, fmt.Errorf("line %d: unknown emoji %q", n, fields[len(fields)-1])
+		}
+		at = next
+		events = append(events, replayEvent{at: at, emoji: emoji})
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, errors.New("no updates to replay")
+	}
+	return events, nil
+}
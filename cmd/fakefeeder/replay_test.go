@@ -0,0 +1,102 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	fakefeeder "github.com/emojitracker/emojitrack-fakefeeder"
+)
+
+var replaySeed = []fakefeeder.Ranking{
+	{Char: "😂", ID: "1F602", Name: "FACE WITH TEARS OF JOY", Score: 300},
+	{Char: "❤️", ID: "2764-FE0F", Name: "RED HEART", Score: 200},
+	{Char: "🔥", ID: "1F525", Name: "FIRE", Score: 100},
+}
+
+func TestReadReplay(t *testing.T) {
+	type event struct {
+		at time.Duration
+		id string
+	}
+	for _, tc := range []struct {
+		name  string
+		input string
+		want  []event
+	}{
+		{
+			name:  "gaps",
+			input: "1F525\n😂\n2764-FE0F\n",
+			want:  []event{{0, "1F525"}, {100 * time.Millisecond, "1F602"}, {200 * time.Millisecond, "2764-FE0F"}},
+		},
+		{
+			name: "offsets and gaps",
+			input: `# a comment
+#
+1F525
+
+  1.5s   1F525
+2s ❤️
+🔥
+`,
+			want: []event{
+				{0, "1F525"},
+				{1500 * time.Millisecond, "1F525"},
+				{2 * time.Second, "2764-FE0F"},
+				{2100 * time.Millisecond, "1F525"},
+			},
+		},
+		{
+			name:  "first offset",
+			input: "1s 1F602\n1F602\n",
+			want:  []event{{time.Second, "1F602"}, {1100 * time.Millisecond, "1F602"}},
+		},
+		{
+			name:  "equal offsets",
+			input: "1s 1F602\n1s 1F525\n",
+			want:  []event{{time.Second, "1F602"}, {time.Second, "1F525"}},
+		},
+		{
+			name:  "no trailing newline",
+			input: "0s 1F602",
+			want:  []event{{0, "1F602"}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			events, err := readReplay(strings.NewReader(tc.input), replaySeed, 100*time.Millisecond)
+			if err != nil {
+				t.Fatalf("readReplay() error = %v", err)
+			}
+			if len(events) != len(tc.want) {
+				t.Fatalf("readReplay() returned %d events, want %d", len(events), len(tc.want))
+			}
+			for i, e := range events {
+				if e.at != tc.want[i].at || e.emoji.ID != tc.want[i].id {
+					t.Errorf("event %d = %v %s, want %v %s", i, e.at, e.emoji.ID, tc.want[i].at, tc.want[i].id)
+				}
+			}
+		})
+	}
+}
+
+func TestReadReplayErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name, input, want string
+	}{
+		{"empty", "", "no updates to replay"},
+		{"only comments", "# nothing\n\n#\n", "no updates to replay"},
+		{"unknown emoji", "1F602\n1F4A9\n", `line 2: unknown emoji "1F4A9"`},
+		{"comment without space", "#1F602\n", `line 1: unknown emoji "#1F602"`},
+		{"invalid offset", "soon 1F602\n", "line 1: invalid offset"},
+		{"backwards offset", "2s 1F602\n1s 1F602\n", "line 2: offset 1s is before the previous update at 2s"},
+		{"backwards after gap", "1s 1F602\n1F602\n1.05s 1F602\n", "line 3: offset 1.05s is before the previous update at 1.1s"},
+		{"too many fields", "1s 1F602 1F525\n", "line 1: want an emoji"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := readReplay(strings.NewReader(tc.input), replaySeed, 100*time.Millisecond)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("readReplay() error = %v, want containing %q", err, tc.want)
+			}
+		})
+	}
+}
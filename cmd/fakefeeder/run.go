@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+)
+
+// cmdRun runs the run command: seed redis (unless -no-seed), then send random
+// updates at a constant rate until interrupted, or run through a scenario.
+func cmdRun(args []string) {
+	fs := newFlagSet("run", "")
+	noSeed := fs.Bool("no-seed", false, "send updates on top of the data already in redis (e.g. from the seed command), rather than seeding it first")
+	e := newEnv(fs, args)
+	e.logger.Info("starting up", "target", e.cfg.Target, "rate", e.cfg.Rate)
+
+	// load the scenario before seeding, so a bad file fails fast
+	var sc *scenario
+	if e.cfg.Scenario != "" {
+		var err error
+		sc, err = readScenario(e.cfg.Scenario)
+		if err != nil {
+			fatal(e.logger, "could not load scenario", err)
+		}
+	} else if e.cfg.Rate == 0 {
+		fatal(e.logger, "rate must be positive", nil)
+	}
+
+	e.loadSeed()
+	e.startFeeder(*noSeed)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if e.cfg.Mirror.URL != "" {
+		if e.cfg.Mirror.Interval <= 0 {
+			fatal(e.logger, "mirror interval must be positive", nil)
+		}
+		e.logger.Info("mirroring rankings", "url", e.cfg.Mirror.URL, "interval", e.cfg.Mirror.Interval)
+		go mirror(ctx, e.feeder, e.cfg.Mirror.URL, e.cfg.Mirror.Interval, e.seed, e.prepare, e.logger)
+	}
+
+	// if scripted, run through the scenario timeline and exit
+	if sc != nil {
+		runner := &scenarioRunner{
+			feeder:    e.feeder,
+			sink:      e.sink,
+			seed:      e.seed,
+			weighting: e.weighting,
+			workers:   e.cfg.Workers,
+			logger:    e.logger,
+		}
+		if err := runner.Run(ctx, sc, float64(e.cfg.Rate)); err != nil && ctx.Err() == nil {
+			fatal(e.logger, "scenario failed", err)
+		}
+		return
+	}
+
+	// start feeding redis random updates
+	e.sendUpdates(ctx)
+	e.logger.Info("stopped sending updates")
+}
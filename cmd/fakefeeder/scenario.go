@@ -0,0 +1,323 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"math/rand"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	fakefeeder "github.com/emojitracker/emojitrack-fakefeeder"
+)
+
+// scenario is a scripted timeline of traffic, executed step by step by a
+// scenarioRunner. A scenario file is YAML of the form:
+//
+//	name: spike and recover
+//	steps:
+//	  - idle: 30s                             # send no updates
+//	  - ramp: {to: 5000, over: 1m}            # linearly change rate
+//	  - spike: {emoji: "🔥", times: 50, for: 20s}
+//	  - disconnect: true                      # drop all feeder redis connections
+//	  - rate: 250                             # set rate immediately
+//	  - hold: 1m                              # keep sending at current rate
+//	  - reset: true                           # restore initial seed state
+//
+// Each step must contain exactly one action.
+type scenario struct {
+	Name  string `yaml:"name"`
+	Steps []step `yaml:"steps"`
+}
+
+type step struct {
+	Idle       time.Duration `yaml:"idle"`
+	Hold       time.Duration `yaml:"hold"`
+	Rate       *float64      `yaml:"rate"`
+	Ramp       *rampStep     `yaml:"ramp"`
+	Spike      *spikeStep    `yaml:"spike"`
+	Disconnect bool          `yaml:"disconnect"`
+	Reset      bool          `yaml:"reset"`
+}
+
+// rampStep linearly changes the update rate from its current value to To,
+// over the duration Over.
+type rampStep struct {
+	To   float64       `yaml:"to"`
+	Over time.Duration `yaml:"over"`
+}
+
+// spikeStep multiplies the probability of Emoji (an ID or char) being chosen by
+// Times, for the duration For. The overall update rate is unaffected.
+type spikeStep struct {
+	Emoji string        `yaml:"emoji"`
+	Times float64       `yaml:"times"`
+	For   time.Duration `yaml:"for"`
+}
+
+// validate checks that s contains exactly one well-formed action.
+func (s step) validate() error {
+	n := 0
+	for _, set := range []bool{
+		s.Idle != 0, s.Hold != 0, s.Rate != nil, s.Ramp != nil,
+		s.Spike != nil, s.Disconnect, s.Reset,
+	} {
+		if set {
+			n++
+		}
+	}
+	switch {
+	case n != 1:
+		return fmt.Errorf("step must contain exactly one action, found %d", n)
+	case s.Idle < 0, s.Hold < 0:
+		return errors.New("duration must not be negative")
+	case s.Rate != nil && *s.Rate < 0:
+		return errors.New("rate must not be negative")
+	case s.Ramp != nil && (s.Ramp.To < 0 || s.Ramp.Over <= 0):
+		return errors.New("ramp requires a non-negative rate and positive duration")
+	case s.Spike != nil && (s.Spike.Emoji == "" || s.Spike.Times <= 0 || s.Spike.For <= 0):
+		return errors.New("spike requires an emoji, positive multiplier and positive duration")
+	}
+	return nil
+}
+
+// readScenario loads and validates the scenario file at path.
+func readScenario(path string) (*scenario, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open scenario file: %w", err)
+	}
+	defer f.Close()
+
+	var sc scenario
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(&sc); err != nil {
+		return nil, fmt.Errorf("could not parse scenario file %s: %w", path, err)
+	}
+	for i, s := range sc.Steps {
+		if err := s.validate(); err != nil {
+			return nil, fmt.Errorf("scenario step %d: %w", i+1, err)
+		}
+	}
+	return &sc, nil
+}
+
+// scenarioRunner executes a scenario against a Feeder, driving updates at a
+// variable rate from its own scheduler rather than Feeder.Start.
+type scenarioRunner struct {
+	feeder    *fakefeeder.Feeder
+	sink      fakefeeder.FaultSink
+	seed      []fakefeeder.Ranking
+	weighting fakefeeder.Weighting // nil for uniform choice
+	workers   int
+	logger    *slog.Logger
+
+	rate  atomic.Uint64 // math.Float64bits of current updates/sec
+	spike atomic.Pointer[activeSpike]
+}
+
+// activeSpike is a spike in progress: each update is sent for emoji with
+// probability p, and otherwise chosen by the Feeder as usual.
+type activeSpike struct {
+	emoji fakefeeder.Ranking
+	p     float64
+}
+
+// scheduler granularity for the scenario runner
+const scenarioTick = 10 * time.Millisecond
+
+func (r *scenarioRunner) setRate(v float64) { r.rate.Store(math.Float64bits(v)) }
+func (r *scenarioRunner) getRate() float64  { return math.Float64frombits(r.rate.Load()) }
+
+// Run executes all steps of sc in order starting at initialRate, returning
+// when the final step completes, a step fails, or ctx is cancelled.
+func (r *scenarioRunner) Run(ctx context.Context, sc *scenario, initialRate float64) error {
+	r.setRate(initialRate)
+
+	ctx, cancel := context.WithCancel(ctx)
+	jobs := make(chan struct{})
+	var wg sync.WaitGroup
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+	workers := r.workers
+	if workers < 1 {
+		workers = 1
+	}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range jobs {
+				r.update()
+			}
+		}()
+	}
+	go func() {
+		r.drive(ctx, jobs)
+		close(jobs)
+	}()
+
+	r.logger.Info("running scenario", "name", sc.Name, "steps", len(sc.Steps))
+	for i, s := range sc.Steps {
+		r.logger.Info("scenario step", "step", i+1, "rate", r.getRate())
+		if err := r.exec(ctx, s); err != nil {
+			return fmt.Errorf("scenario step %d: %w", i+1, err)
+		}
+	}
+	r.logger.Info("scenario complete", "name", sc.Name)
+	return nil
+}
+
+// drive sends one job on jobs for every update due at the current rate, until
+// ctx is cancelled.
+func (r *scenarioRunner) drive(ctx context.Context, jobs chan<- struct{}) {
+	ticker := time.NewTicker(scenarioTick)
+	defer ticker.Stop()
+
+	last := time.Now()
+	var due float64
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			due += r.getRate() * now.Sub(last).Seconds()
+			last = now
+			for ; due >= 1; due-- {
+				select {
+				case jobs <- struct{}{}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}
+}
+
+// update sends a single update, accounting for any active spike.
+func (r *scenarioRunner) update() {
+	var err error
+	if s := r.spike.Load(); s != nil && rand.Float64() < s.p {
+		err = r.feeder.UpdateEmoji(s.emoji)
+	} else {
+		err = r.feeder.Update()
+	}
+	if err != nil && !errors.Is(err, fakefeeder.ErrSinkDown) {
+		r.logger.Error("update failed", "error", err, "error_class", fakefeeder.ErrorClass(err))
+	}
+}
+
+// exec performs a single scenario step, blocking for its duration.
+func (r *scenarioRunner) exec(ctx context.Context, s step) error {
+	switch {
+	case s.Idle != 0:
+		prev := r.getRate()
+		r.setRate(0)
+		defer r.setRate(prev)
+		return sleep(ctx, s.Idle)
+	case s.Hold != 0:
+		return sleep(ctx, s.Hold)
+	case s.Rate != nil:
+		r.setRate(*s.Rate)
+		return nil
+	case s.Ramp != nil:
+		return r.ramp(ctx, *s.Ramp)
+	case s.Spike != nil:
+		return r.runSpike(ctx, *s.Spike)
+	case s.Disconnect:
+		return r.sink.DropConnections()
+	case s.Reset:
+		return r.feeder.Reset()
+	}
+	return nil
+}
+
+func (r *scenarioRunner) ramp(ctx context.Context, rs rampStep) error {
+	from := r.getRate()
+	start := time.Now()
+	ticker := time.NewTicker(scenarioTick)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case now := <-ticker.C:
+			frac := float64(now.Sub(start)) / float64(rs.Over)
+			if frac >= 1 {
+				r.setRate(rs.To)
+				return nil
+			}
+			r.setRate(from + (rs.To-from)*frac)
+		}
+	}
+}
+
+func (r *scenarioRunner) runSpike(ctx context.Context, ss spikeStep) error {
+	emoji, ok := r.lookup(ss.Emoji)
+	if !ok {
+		return fmt.Errorf("unknown emoji %q", ss.Emoji)
+	}
+
+	// With a usual share s of updates, the spiked share is s*times normalized
+	// against the rest of the distribution. Since the Feeder may still choose
+	// the emoji itself, only the difference needs to be forced.
+	s := r.share(emoji)
+	spiked := math.Min(s*ss.Times/(1-s+s*ss.Times), 1)
+	p := 1.0
+	if s < 1 {
+		p = math.Max((spiked-s)/(1-s), 0)
+	}
+
+	r.spike.Store(&activeSpike{emoji: emoji, p: p})
+	defer r.spike.Store(nil)
+	return sleep(ctx, ss.For)
+}
+
+// lookup finds the seed ranking matching an emoji ID or char.
+func (r *scenarioRunner) lookup(emoji string) (fakefeeder.Ranking, bool) {
+	return lookupEmoji(r.seed, emoji)
+}
+
+// share returns the usual probability of emoji being chosen by Feeder.Update.
+func (r *scenarioRunner) share(emoji fakefeeder.Ranking) float64 {
+	uniform := 1 / float64(len(r.seed))
+	if r.weighting == nil {
+		return uniform
+	}
+	weights, err := r.weighting(r.seed)
+	if err != nil {
+		return uniform
+	}
+	var total, w float64
+	for i, e := range r.seed {
+		total += weights[i]
+		if e.ID == emoji.ID {
+			w = weights[i]
+		}
+	}
+	if total == 0 {
+		return uniform
+	}
+	return w / total
+}
+
+// sleep blocks for d, or until ctx is cancelled.
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStepValidate(t *testing.T) {
+	rate := func(v float64) *float64 { return &v }
+	for _, tc := range []struct {
+		name string
+		step step
+		want string // error substring, or empty if valid
+	}{
+		{"idle", step{Idle: time.Second}, ""},
+		{"hold", step{Hold: time.Minute}, ""},
+		{"rate", step{Rate: rate(250)}, ""},
+		{"zero rate", step{Rate: rate(0)}, ""},
+		{"ramp", step{Ramp: &rampStep{To: 5000, Over: time.Minute}}, ""},
+		{"ramp to zero", step{Ramp: &rampStep{To: 0, Over: time.Second}}, ""},
+		{"spike", step{Spike: &spikeStep{Emoji: "🔥", Times: 50, For: 20 * time.Second}}, ""},
+		{"spike below one", step{Spike: &spikeStep{Emoji: "1F525", Times: 0.5, For: time.Second}}, ""},
+		{"disconnect", step{Disconnect: true}, ""},
+		{"reset", step{Reset: true}, ""},
+
+		{"empty", step{}, "exactly one action, found 0"},
+		{"two actions", step{Idle: time.Second, Reset: true}, "exactly one action, found 2"},
+		{"all actions", step{
+			Idle: 1, Hold: 1, Rate: rate(1), Ramp: &rampStep{}, Spike: &spikeStep{},
+			Disconnect: true, Reset: true,
+		}, "exactly one action, found 7"},
+		{"negative idle", step{Idle: -time.Second}, "duration must not be negative"},
+		{"negative hold", step{Hold: -time.Second}, "duration must not be negative"},
+		{"negative rate", step{Rate: rate(-1)}, "rate must not be negative"},
+		{"ramp to negative", step{Ramp: &rampStep{To: -1, Over: time.Second}}, "ramp requires"},
+		{"ramp over zero", step{Ramp: &rampStep{To: 10}}, "ramp requires"},
+		{"spike without emoji", step{Spike: &spikeStep{Times: 2, For: time.Second}}, "spike requires"},
+		{"spike zero times", step{Spike: &spikeStep{Emoji: "🔥", For: time.Second}}, "spike requires"},
+		{"spike for zero", step{Spike: &spikeStep{Emoji: "🔥", Times: 2}}, "spike requires"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.step.validate()
+			switch {
+			case tc.want == "" && err != nil:
+				t.Errorf("validate() = %v, want nil", err)
+			case tc.want != "" && (err == nil || !strings.Contains(err.Error(), tc.want)):
+				t.Errorf("validate() = %v, want containing %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestReadScenario(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scenario.yml")
+	if err := os.WriteFile(path, []byte(`
+name: test
+steps:
+  - idle: 30s
+  - rate: 0
+  - ramp: {to: 5000, over: 1m}
+  - spike: {emoji: "🔥", times: 50, for: 20s}
+  - disconnect: true
+  - hold: 1m30s
+  - reset: true
+`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	sc, err := readScenario(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sc.Name != "test" || len(sc.Steps) != 7 {
+		t.Fatalf("readScenario() = %q with %d steps, want test with 7", sc.Name, len(sc.Steps))
+	}
+	s := sc.Steps
+	switch {
+	case s[0].Idle != 30*time.Second:
+		t.Errorf("idle = %v", s[0].Idle)
+	case s[1].Rate == nil || *s[1].Rate != 0:
+		t.Errorf("rate = %v, want explicit 0", s[1].Rate)
+	case *s[2].Ramp != rampStep{To: 5000, Over: time.Minute}:
+		t.Errorf("ramp = %+v", *s[2].Ramp)
+	case *s[3].Spike != spikeStep{Emoji: "🔥", Times: 50, For: 20 * time.Second}:
+		t.Errorf("spike = %+v", *s[3].Spike)
+	case !s[4].Disconnect:
+		t.Error("disconnect not set")
+	case s[5].Hold != 90*time.Second:
+		t.Errorf("hold = %v", s[5].Hold)
+	case !s[6].Reset:
+		t.Error("reset not set")
+	}
+}
+
+func TestReadScenarioErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name, yaml, want string
+	}{
+		{"unknown action", "steps:\n  - sleep: 1s\n", "field sleep not found"},
+		{"unknown spike field", "steps:\n  - spike: {emoji: x, times: 2, for: 1s, every: 1m}\n", "field every not found"},
+		{"invalid duration", "steps:\n  - idle: forever\n", "could not parse scenario file"},
+		{"invalid step", "steps:\n  - idle: 1s\n  - {idle: 1s, reset: true}\n", "scenario step 2: step must contain exactly one action"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "scenario.yml")
+			if err := os.WriteFile(path, []byte(tc.yaml), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := readScenario(path)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("readScenario() error = %v, want containing %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestBundledScenarios(t *testing.T) {
+	paths, err := filepath.Glob("../../scenarios/*.yml")
+	if err != nil || len(paths) == 0 {
+		t.Fatalf("no bundled scenarios found: %v", err)
+	}
+	for _, path := range paths {
+		if _, err := readScenario(path); err != nil {
+			t.Errorf("bundled scenario %s: %v", path, err)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import "time"
+
+// cmdSeed runs the seed command: seed redis with the initial state, then exit,
+// leaving it for consumers (or a later run -no-seed) to use.
+//
+// Seeding overwrites the scores and tweets of the seeded emoji, but leaves any
+// other data in place; see cmdReset to start from a clean slate.
+func cmdSeed(args []string) {
+	fs := newFlagSet("seed", "")
+	e := newEnv(fs, args)
+	e.loadSeed()
+
+	start := time.Now()
+	e.startFeeder(false)
+	e.logger.Info("seeded", "target", e.cfg.Target, "emoji", len(e.seed), "elapsed", time.Since(start))
+}
+
+// cmdReset runs the reset command: clear all scores, and the tweets of every
+// emoji in the seed data, then seed again and exit.
+func cmdReset(args []string) {
+	fs := newFlagSet("reset", "")
+	e := newEnv(fs, args)
+	e.loadSeed()
+
+	start := time.Now()
+	e.startFeeder(true)
+	if err := e.feeder.Reset(); err != nil {
+		fatal(e.logger, "could not reset", err)
+	}
+	e.logger.Info("reset", "target", e.cfg.Target, "emoji", len(e.seed), "elapsed", time.Since(start))
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"time"
+
+	fakefeeder "github.com/emojitracker/emojitrack-fakefeeder"
+)
+
+// maxServeUpdates is the most updates a single request to /update may send.
+const maxServeUpdates = 100000
+
+// cmdServe runs the serve command: seed redis (unless -no-seed), send random
+// updates at the configured rate in the background (none if it is 0), and
+// serve HTTP endpoints for controlling the feeder until interrupted:
+//
+//	GET  /healthz                  health of the redis connection
+//	GET  /rankings                 current scores, in the rankings API format
+//	POST /update?emoji=ID&n=COUNT  send n updates (default 1) for emoji (default random)
+//	POST /reset                    restore the initial seed state
+func cmdServe(args []string) {
+	fs := newFlagSet("serve", "")
+	noSeed := fs.Bool("no-seed", false, "serve the data already in redis (e.g. from the seed command), rather than seeding it first")
+	e := newEnv(fs, args)
+	e.loadSeed()
+	e.startFeeder(*noSeed)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if e.cfg.Rate > 0 {
+		go e.sendUpdates(ctx)
+	}
+
+	srv := &http.Server{
+		Addr:              e.cfg.Listen,
+		Handler:           (&server{e}).handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+	e.logger.Info("serving", "addr", e.cfg.Listen)
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		fatal(e.logger, "could not serve", err)
+	}
+	e.logger.Info("stopped serving")
+}
+
+// server serves the HTTP endpoints of the serve command.
+type server struct {
+	e *env
+}
+
+func (s *server) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", method(http.MethodGet, s.healthz))
+	mux.HandleFunc("/rankings", method(http.MethodGet, s.rankings))
+	mux.HandleFunc("/update", method(http.MethodPost, s.update))
+	mux.HandleFunc("/reset", method(http.MethodPost, s.reset))
+	return mux
+}
+
+// method wraps h to reject requests with any method but m.
+func method(m string, h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != m {
+			w.Header().Set("Allow", m)
+			httpError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+			return
+		}
+		h(w, r)
+	}
+}
+
+func (s *server) healthz(w http.ResponseWriter, r *http.Request) {
+	health := s.e.feeder.Health()
+	code := http.StatusOK
+	if health == fakefeeder.Down {
+		code = http.StatusServiceUnavailable
+	}
+	writeJSON(w, code, map[string]string{"health": health.String()})
+}
+
+func (s *server) rankings(w http.ResponseWriter, r *http.Request) {
+	c, err := s.e.pool.GetContext(r.Context())
+	if err != nil {
+		httpError(w, http.StatusBadGateway, err)
+		return
+	}
+	defer c.Close()
+	scores, err := readScores(c, s.e.cfg.Namespace)
+	if err != nil {
+		httpError(w, http.StatusBadGateway, err)
+		return
+	}
+
+	rs := make([]fakefeeder.Ranking, 0, len(s.e.seed))
+	for _, seed := range s.e.seed {
+		if score, ok := scores[seed.ID]; ok {
+			rs = append(rs, fakefeeder.Ranking{Char: seed.Char, ID: seed.ID, Name: seed.Name, Score: score})
+		}
+	}
+	sort.SliceStable(rs, func(i, j int) bool { return rs[i].Score > rs[j].Score })
+	writeJSON(w, http.StatusOK, rs)
+}
+
+func (s *server) update(w http.ResponseWriter, r *http.Request) {
+	n := 1
+	if v := r.FormValue("n"); v != "" {
+		var err error
+		n, err = strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxServeUpdates {
+			httpError(w, http.StatusBadRequest, fmt.Errorf("n must be from 1 to %d", maxServeUpdates))
+			return
+		}
+	}
+	var emoji *fakefeeder.Ranking
+	if v := r.FormValue("emoji"); v != "" {
+		e, ok := lookupEmoji(s.e.seed, v)
+		if !ok {
+			httpError(w, http.StatusNotFound, fmt.Errorf("unknown emoji %q", v))
+			return
+		}
+		emoji = &e
+	}
+
+	sent := 0
+	for ; sent < n && r.Context().Err() == nil; sent++ {
+		var err error
+		if emoji != nil {
+			err = s.e.feeder.UpdateEmoji(*emoji)
+		} else {
+			err = s.e.feeder.Update()
+		}
+		if err != nil {
+			code := http.StatusBadGateway
+			if errors.Is(err, fakefeeder.ErrSinkDown) {
+				code = http.StatusServiceUnavailable
+			}
+			writeJSON(w, code, map[string]any{"sent": sent, "error": err.Error()})
+			return
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"sent": sent})
+}
+
+func (s *server) reset(w http.ResponseWriter, r *http.Request) {
+	if err := s.e.feeder.Reset(); err != nil {
+		httpError(w, http.StatusBadGateway, err)
+		return
+	}
+	s.e.logger.Info("reset", "emoji", len(s.e.seed))
+	writeJSON(w, http.StatusOK, map[string]any{"reset": len(s.e.seed)})
+}
+
+// writeJSON writes v as the JSON response body with status code.
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
+
+// httpError writes err as a JSON error response with status code.
+func httpError(w http.ResponseWriter, code int, err error) {
+	writeJSON(w, code, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	fakefeeder "github.com/emojitracker/emojitrack-fakefeeder"
+	"github.com/emojitracker/emojitrack-fakefeeder/rankings"
+)
+
+// cmdSnapshot runs the snapshot command: print the seed data, after any
+// synthesizing and filtering, as it would be seeded to redis, along with the
+// chance of each emoji being chosen for an update under the configured
+// distribution. With -list, it instead lists the bundled snapshots.
+//
+// The json format is that of the rankings API (with any metadata added), so
+// its output can be served to another feeder as seed data.
+func cmdSnapshot(args []string) {
+	fs := newFlagSet("snapshot", "")
+	format := fs.String("format", "table", "output `format`: table, csv or json")
+	list := fs.Bool("list", false, "list the bundled snapshots instead")
+	e := newEnv(fs, args)
+	switch *format {
+	case "table", "csv", "json":
+	default:
+		fatal(e.logger, fmt.Sprintf("unknown format %q", *format), nil)
+	}
+	if *list {
+		if err := listSnapshots(); err != nil {
+			fatal(e.logger, "could not list snapshots", err)
+		}
+		return
+	}
+	e.loadSeed()
+
+	var err error
+	switch *format {
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(e.seed)
+	case "csv":
+		err = writeSeedCSV(e.seed, chances(e.cfg, e.seed))
+	case "table":
+		err = writeSeedTable(e.seed, chances(e.cfg, e.seed))
+	}
+	if err != nil {
+		fatal(e.logger, "could not write seed data", err)
+	}
+}
+
+// listSnapshots prints a table of the bundled snapshots.
+func listSnapshots() error {
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tEMOJI\tCREATED\tSOURCE")
+	for _, name := range rankings.Snapshots() {
+		sf, err := rankings.NamedSnapshotFile(name)
+		if err != nil {
+			return err
+		}
+		if name == rankings.DefaultSnapshot {
+			name += " (default)"
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\n", name, len(sf.Rankings), sf.CreatedAt.Format("2006-01-02"), sf.Source)
+	}
+	return tw.Flush()
+}
+
+// chances returns the initial probability of each of rs being chosen for an
+// update under the distribution configured by cfg, or nil if it is invalid.
+func chances(cfg *config, rs []fakefeeder.Ranking) []float64 {
+	weighting, _, err := distribution(cfg)
+	if err != nil {
+		return nil
+	}
+	if weighting == nil { // round-robin
+		weighting = fakefeeder.Uniform()
+	}
+	ws, err := weighting(rs)
+	if err != nil {
+		return nil
+	}
+	var total float64
+	for _, w := range ws {
+		total += w
+	}
+	for i := range ws {
+		if total > 0 {
+			ws[i] /= total
+		} else {
+			ws[i] = 1 / float64(len(ws))
+		}
+	}
+	return ws
+}
+
+// writeSeedTable prints rs as a table, with the chance of each being chosen.
+func writeSeedTable(rs []fakefeeder.Ranking, chance []float64) error {
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "RANK\tID\tCHAR\tSCORE\tCHANCE\tNAME")
+	for i, r := range rs {
+		c := "-"
+		if chance != nil {
+			c = fmt.Sprintf("%.4f%%", chance[i]*100)
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%d\t%s\t%s\n", i+1, r.ID, r.Char, r.Score, c, r.Name)
+	}
+	return tw.Flush()
+}
+
+// writeSeedCSV prints rs as CSV, with any metadata and the chance of each
+// being chosen.
+func writeSeedCSV(rs []fakefeeder.Ranking, chance []float64) error {
+	w := csv.NewWriter(os.Stdout)
+	w.Write([]string{"id", "char", "name", "score", "chance", "category", "subcategory", "emoji_version"})
+	for i, r := range rs {
+		record := []string{r.ID, r.Char, r.Name, strconv.FormatInt(r.Score, 10), "", "", "", ""}
+		if chance != nil {
+			record[4] = strconv.FormatFloat(chance[i], 'g', -1, 64)
+		}
+		if r.Meta != nil {
+			record[5], record[6], record[7] = r.Meta.Category, r.Meta.Subcategory, r.Meta.EmojiVersion
+		}
+		w.Write(record)
+	}
+	w.Flush()
+	return w.Error()
+}
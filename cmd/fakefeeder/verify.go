@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/gomodule/redigo/redis"
+
+	fakefeeder "github.com/emojitracker/emojitrack-fakefeeder"
+)
+
+// cmdVerify runs the verify command: check that redis holds valid data for
+// every emoji in the seed data, as a consumer would read it, exiting non-zero
+// if any problems are found.
+//
+// Each emoji must have a score of at least its seed score (exactly its seed
+// score with -exact), and between 1 and the history depth of valid tweets.
+// Synthesized scores are random unless -synth-seed is set, so without it they
+// only need to exist. Tweets are expected newest first, but concurrent workers
+// may push them slightly out of order, so that is only counted in the summary.
+func cmdVerify(args []string) {
+	fs := newFlagSet("verify", "")
+	exact := fs.Bool("exact", false, "require every score to equal its seed score, as immediately after seed or reset")
+	e := newEnv(fs, args)
+	e.loadSeed()
+	e.connect()
+
+	c := e.pool.Get()
+	defer c.Close()
+	scores, err := readScores(c, e.cfg.Namespace)
+	if err != nil {
+		fatal(e.logger, "could not read scores", err)
+	}
+	tweets, err := readTweets(c, e.cfg.Namespace, e.seed)
+	if err != nil {
+		fatal(e.logger, "could not read tweets", err)
+	}
+
+	depth := e.cfg.HistoryDepth
+	if depth <= 0 {
+		depth = fakefeeder.DefaultHistoryDepth
+	}
+	randomSynth := len(e.synthesized) > 0 && e.randomSynth
+	if randomSynth {
+		e.logger.Info("synthesized scores are random without -synth-seed, only checking they exist", "synthesized", len(e.synthesized))
+	}
+	var problems, unordered int
+	problem := func(r fakefeeder.Ranking, msg string, args ...any) {
+		problems++
+		e.logger.Warn(msg, append([]any{"emoji_id", r.ID, "emoji", r.Char}, args...)...)
+	}
+	for i, r := range e.seed {
+		score, ok := scores[r.ID]
+		switch {
+		case !ok:
+			problem(r, "missing score")
+		case randomSynth && e.synthesized[r.ID]:
+			// any score will do
+		case score < r.Score:
+			problem(r, "score below seed score", "score", score, "seed_score", r.Score)
+		case *exact && score != r.Score:
+			problem(r, "score differs from seed score", "score", score, "seed_score", r.Score)
+		}
+		ordered, err := checkTweets(tweets[i], depth)
+		if err != nil {
+			problem(r, "invalid tweets", "error", err)
+		} else if !ordered {
+			unordered++
+			e.logger.Debug("tweets out of order", "emoji_id", r.ID, "emoji", r.Char)
+		}
+	}
+
+	extra := len(scores)
+	for _, r := range e.seed {
+		if _, ok := scores[r.ID]; ok {
+			extra--
+		}
+	}
+	e.logger.Info("verify complete", "target", e.cfg.Target, "emoji", len(e.seed), "unseeded_scores", extra, "out_of_order", unordered, "problems", problems)
+	if problems > 0 {
+		os.Exit(1)
+	}
+}
+
+// readScores returns all scores in redis (in namespace) by emoji ID.
+func readScores(c redis.Conn, namespace string) (map[string]int64, error) {
+	return redis.Int64Map(c.Do("ZRANGE", fakefeeder.Namespaced(namespace, fakefeeder.ScoreKey), 0, -1, "WITHSCORES"))
+}
+
+// readTweets returns the stored tweets (in namespace) of each of rs, in a
+// single round trip.
+func readTweets(c redis.Conn, namespace string, rs []fakefeeder.Ranking) ([][][]byte, error) {
+	prefix := fakefeeder.Namespaced(namespace, fakefeeder.TweetKeyPrefix)
+	for _, r := range rs {
+		if err := c.Send("LRANGE", prefix+r.ID, 0, -1); err != nil {
+			return nil, err
+		}
+	}
+	if err := c.Flush(); err != nil {
+		return nil, err
+	}
+	tweets := make([][][]byte, len(rs))
+	for i := range rs {
+		var err error
+		tweets[i], err = redis.ByteSlices(c.Receive())
+		if err != nil && err != redis.ErrNil {
+			return nil, err
+		}
+	}
+	return tweets, nil
+}
+
+// checkTweets returns an error unless tweets holds between 1 and depth valid
+// tweets, and reports whether they are in order, newest first.
+func checkTweets(tweets [][]byte, depth int) (ordered bool, err error) {
+	if len(tweets) == 0 || len(tweets) > depth {
+		return false, fmt.Errorf("have %d tweets, want 1 to %d", len(tweets), depth)
+	}
+	ordered = true
+	var last int64
+	for i, data := range tweets {
+		_, id, err := decodeTweet(data)
+		if err != nil {
+			return false, fmt.Errorf("tweet %d: %w", i+1, err)
+		}
+		if i > 0 && id >= last {
+			ordered = false
+		}
+		last = id
+	}
+	return ordered, nil
+}
@@ -0,0 +1,284 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+
+	fakefeeder "github.com/emojitracker/emojitrack-fakefeeder"
+)
+
+// cmdWatch runs the watch command: a consumer subscribing to the streams
+// published by a feeder, which checks every message and periodically reports
+// statistics. It exits non-zero if any violations were seen, or if -for was
+// given and no updates were received at all.
+func cmdWatch(args []string) {
+	fs := newFlagSet("watch", "")
+	interval := fs.Duration("interval", 10*time.Second, "how often to report statistics")
+	duration := fs.Duration("for", 0, "stop watching after this long (0 to watch until interrupted)")
+	e := newEnv(fs, args)
+	cfg, logger := e.cfg, e.logger
+	if *interval <= 0 {
+		fatal(logger, "report interval must be positive", nil)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if *duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *duration)
+		defer cancel()
+	}
+
+	w := &watcher{logger: logger, namespace: cfg.Namespace, lastTweet: make(map[string]int64), pending: make(map[string]int)}
+	logger.Info("watching streams", "target", cfg.Target, "interval", *interval)
+	go w.report(ctx, *interval)
+	w.subscribe(ctx, cfg)
+
+	total := w.summary()
+	logger.Info("watch complete", total...)
+	if w.totalViolations > 0 || (*duration > 0 && w.totalScores == 0) {
+		os.Exit(1)
+	}
+}
+
+// watcher tracks the statistics of the messages received by watch.
+type watcher struct {
+	logger    *slog.Logger
+	namespace string // of the channels watched, as configured for the feeder
+
+	mu         sync.Mutex
+	start      time.Time
+	scores     int             // score updates received this interval
+	tweets     int             // tweet updates received this interval
+	latencies  []time.Duration // tweet latencies this interval
+	outOfOrder int             // messages this interval arriving out of order
+	violations int             // invalid messages this interval
+
+	totalScores, totalTweets, totalOutOfOrder, totalViolations int
+
+	lastTweet map[string]int64 // last tweet ID seen for each emoji
+	pending   map[string]int   // score updates awaiting their tweet update, by emoji
+}
+
+// emojiIDPattern matches emojitracker style emoji IDs, e.g. "1F469-200D-1F4BB".
+var emojiIDPattern = regexp.MustCompile(`^[0-9A-F]{4,6}(-[0-9A-F]{4,6})*$`)
+
+// subscribe receives messages until ctx is done, reconnecting whenever the
+// connection to redis fails.
+func (w *watcher) subscribe(ctx context.Context, cfg *config) {
+	w.mu.Lock()
+	w.start = time.Now()
+	w.mu.Unlock()
+
+	for ctx.Err() == nil {
+		err := w.receive(ctx, cfg)
+		if ctx.Err() != nil {
+			return
+		}
+		w.logger.Warn("lost subscription, reconnecting", "error", err)
+		if sleep(ctx, time.Second) != nil {
+			return
+		}
+	}
+}
+
+// receive subscribes to the streams on a single connection, and handles
+// messages until the connection fails or ctx is done.
+func (w *watcher) receive(ctx context.Context, cfg *config) error {
+	c, err := redis.DialURL(cfg.Target,
+		redis.DialClientName(fakefeeder.ClientName+"-watch"), // survive DropConnections
+		redis.DialConnectTimeout(cfg.Pool.ConnectTimeout),
+		redis.DialWriteTimeout(cfg.Pool.WriteTimeout),
+	)
+	if err != nil {
+		return err
+	}
+	psc := redis.PubSubConn{Conn: c}
+	defer psc.Close()
+	stop := context.AfterFunc(ctx, func() { psc.Close() })
+	defer stop()
+
+	if err := psc.Subscribe(w.channel(fakefeeder.ScoreUpdatesChannel)); err != nil {
+		return err
+	}
+	if err := psc.PSubscribe(w.channel(fakefeeder.TweetUpdatesPrefix) + "*"); err != nil {
+		return err
+	}
+	for {
+		switch m := psc.Receive().(type) {
+		case redis.Message:
+			w.handle(m.Channel, m.Data, time.Now())
+		case error:
+			return m
+		}
+	}
+}
+
+// channel returns the name of channel within the watched namespace.
+func (w *watcher) channel(channel string) string {
+	return fakefeeder.Namespaced(w.namespace, channel)
+}
+
+// handle checks a single message received at time at.
+func (w *watcher) handle(channel string, data []byte, at time.Time) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	tweetPrefix := w.channel(fakefeeder.TweetUpdatesPrefix)
+	switch {
+	case channel == w.channel(fakefeeder.ScoreUpdatesChannel):
+		w.scores++
+		id := string(data)
+		if !emojiIDPattern.MatchString(id) {
+			w.violation("invalid emoji ID in score update", "channel", channel, "id", id)
+			return
+		}
+		w.pending[id]++
+
+	case strings.HasPrefix(channel, tweetPrefix):
+		w.tweets++
+		id := strings.TrimPrefix(channel, tweetPrefix)
+		if !emojiIDPattern.MatchString(id) {
+			w.violation("invalid emoji ID in tweet channel", "channel", channel)
+			return
+		}
+		tweet, tweetID, err := decodeTweet(data)
+		if err != nil {
+			w.violation("invalid tweet", "channel", channel, "error", err)
+			return
+		}
+		w.latencies = append(w.latencies, at.Sub(tweet.CreatedAt))
+
+		// every tweet update should follow its score update, and tweets for an
+		// emoji should arrive in the order they were created
+		if w.pending[id] > 0 {
+			w.pending[id]--
+		} else {
+			w.outOfOrder++
+			w.logger.Debug("tweet update without preceding score update", "emoji_id", id, "tweet_id", tweet.ID)
+		}
+		if last := w.lastTweet[id]; tweetID <= last {
+			w.outOfOrder++
+			w.logger.Debug("tweet out of order", "emoji_id", id, "tweet_id", tweet.ID, "previous_tweet_id", last)
+		} else {
+			w.lastTweet[id] = tweetID
+		}
+
+	default:
+		w.violation("message on unexpected channel", "channel", channel)
+	}
+}
+
+// violation records and logs an invalid message. The caller must hold w.mu.
+func (w *watcher) violation(msg string, args ...any) {
+	w.violations++
+	w.logger.Warn(msg, args...)
+}
+
+// decodeTweet strictly decodes an EnsmallenedTweet, returning it along with
+// its parsed ID, or an error describing how it violates the schema.
+func decodeTweet(data []byte) (fakefeeder.EnsmallenedTweet, int64, error) {
+	var t fakefeeder.EnsmallenedTweet
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&t); err != nil {
+		return t, 0, err
+	}
+	id, err := strconv.ParseInt(t.ID, 10, 64)
+	switch {
+	case err != nil:
+		return t, 0, fmt.Errorf("non-numeric id %q", t.ID)
+	case t.Text == "", t.ScreenName == "", t.Name == "":
+		return t, 0, errors.New("missing text, screen_name or name")
+	case t.CreatedAt.IsZero():
+		return t, 0, errors.New("missing created_at")
+	case !fakefeeder.TweetIDTime(id).Equal(t.CreatedAt.Truncate(time.Millisecond)):
+		return t, 0, fmt.Errorf("created_at %v does not match time of id %s", t.CreatedAt, t.ID)
+	}
+	return t, id, nil
+}
+
+// report logs the statistics for each interval until ctx is done.
+func (w *watcher) report(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	last := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			w.mu.Lock()
+			elapsed := now.Sub(last).Seconds()
+			args := []any{
+				"score_updates", w.scores,
+				"tweet_updates", w.tweets,
+				"rate", float64(w.scores) / elapsed,
+				"out_of_order", w.outOfOrder,
+				"violations", w.violations,
+			}
+			args = append(args, latencyStats(w.latencies)...)
+			w.totalScores += w.scores
+			w.totalTweets += w.tweets
+			w.totalOutOfOrder += w.outOfOrder
+			w.totalViolations += w.violations
+			w.scores, w.tweets, w.outOfOrder, w.violations = 0, 0, 0, 0
+			w.latencies = w.latencies[:0]
+			w.mu.Unlock()
+
+			last = now
+			w.logger.Info("watch stats", args...)
+		}
+	}
+}
+
+// summary folds any unreported statistics into the totals, and returns them as
+// log attributes.
+func (w *watcher) summary() []any {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.totalScores += w.scores
+	w.totalTweets += w.tweets
+	w.totalOutOfOrder += w.outOfOrder
+	w.totalViolations += w.violations
+	w.scores, w.tweets, w.outOfOrder, w.violations = 0, 0, 0, 0
+
+	elapsed := time.Since(w.start).Seconds()
+	return []any{
+		"score_updates", w.totalScores,
+		"tweet_updates", w.totalTweets,
+		"rate", float64(w.totalScores) / elapsed,
+		"out_of_order", w.totalOutOfOrder,
+		"violations", w.totalViolations,
+	}
+}
+
+// latencyStats returns log attributes summarizing ls, which it sorts.
+func latencyStats(ls []time.Duration) []any {
+	if len(ls) == 0 {
+		return nil
+	}
+	sort.Slice(ls, func(i, j int) bool { return ls[i] < ls[j] })
+	pct := func(p float64) time.Duration {
+		return ls[int(p*float64(len(ls)-1))]
+	}
+	return []any{
+		"latency_p50", pct(0.5),
+		"latency_p99", pct(0.99),
+		"latency_max", ls[len(ls)-1],
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/icrowley/fake"
@@ -14,11 +15,29 @@ type Ranking struct {
 	Char  string `json:"char"`
 	ID    string `json:"id"`
 	Name  string `json:"name"`
-	Score int    `json:"score"`
+	Score int64  `json:"score"`
+
+	// Meta is optional Unicode metadata, which is not provided by the API but
+	// may be joined on from bundled data, see rankings.WithMetadata.
+	Meta *Metadata `json:"meta,omitempty"`
 }
 
-// a semi realistic scale of where tweet IDs currently are at
-var globalTweetID = 769706198425825280
+// Metadata describes an emoji per the Unicode emoji data files.
+type Metadata struct {
+	Category     string `json:"category"`      // group, e.g. "Smileys & Emotion"
+	Subcategory  string `json:"subcategory"`   // subgroup, e.g. "face-smiling"
+	EmojiVersion string `json:"emoji_version"` // version of Unicode Emoji which introduced it, e.g. "13.0"
+	ZWJ          bool   `json:"zwj"`           // is a zero width joiner sequence
+	SkinTones    bool   `json:"skin_tones"`    // supports skin tone modifiers
+}
+
+// tweetIDs issues snowflake IDs for all generated tweets, so that IDs are
+// unique and encode the same timestamp as CreatedAt. Backdated tweets use a
+// separate worker ID, so they can never collide with current ones.
+var (
+	tweetIDs    = &snowflake{}
+	backdateIDs = &snowflake{worker: 1}
+)
 
 // EnsmallenedTweet matches the structure used by emojitrack-feeder for sending
 // out bandwidth efficient tweets.
@@ -42,16 +61,34 @@ func (t *EnsmallenedTweet) MustEncode() []byte {
 	return b
 }
 
+// fakeMu serializes access to github.com/icrowley/fake, which keeps its own
+// unsynchronized random source and sample cache.
+var fakeMu sync.Mutex
+
 func randomTweetForEmoji(r Ranking) EnsmallenedTweet {
-	globalTweetID += 42
+	id, createdAt := tweetIDs.next(time.Now())
+	return randomTweet(r, id, createdAt)
+}
+
+// backdatedTweetForEmoji is like randomTweetForEmoji, but for a tweet created
+// at a time t in the past.
+func backdatedTweetForEmoji(r Ranking, t time.Time) EnsmallenedTweet {
+	id, createdAt := backdateIDs.at(t)
+	return randomTweet(r, id, createdAt)
+}
+
+func randomTweet(r Ranking, id int64, createdAt time.Time) EnsmallenedTweet {
+	fakeMu.Lock()
+	sentence, userName, fullName := fake.Sentence(), fake.UserName(), fake.FullName()
+	fakeMu.Unlock()
 
 	return EnsmallenedTweet{
-		ID:              strconv.Itoa(globalTweetID),
-		Text:            fmt.Sprintf("%s %s", fake.Sentence(), r.Char),
-		ScreenName:      fake.UserName(),
-		Name:            fake.FullName(),
+		ID:              strconv.FormatInt(id, 10),
+		Text:            fmt.Sprintf("%s %s", sentence, r.Char),
+		ScreenName:      userName,
+		Name:            fullName,
 		Links:           []string{},
 		ProfileImageURL: "https://abs.twimg.com/sticky/default_profile_images/default_profile_normal.png",
-		CreatedAt:       time.Now(),
+		CreatedAt:       createdAt,
 	}
 }
@@ -0,0 +1,166 @@
+package fakefeeder
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Dynamics determines how the probability of each emoji being chosen by
+// Feeder.Update changes over time as updates are sent.
+type Dynamics int
+
+const (
+	// Static keeps the initial probabilities fixed.
+	Static Dynamics = iota
+	// RichGetRicher adds weight to an emoji for every update sent for it
+	// (Options.Gain), so weights follow the running scores, and frequently
+	// chosen emoji become ever more likely to be chosen.
+	RichGetRicher
+	// Flatten drifts the probabilities exponentially from their initial values
+	// toward a uniform distribution, with half-life Options.FlattenHalfLife.
+	Flatten
+)
+
+// DefaultFlattenHalfLife is the default Options.FlattenHalfLife.
+const DefaultFlattenHalfLife = time.Hour
+
+var dynamicsNames = []string{
+	Static:        "static",
+	RichGetRicher: "rich-get-richer",
+	Flatten:       "flatten",
+}
+
+func (d Dynamics) String() string {
+	if d >= 0 && int(d) < len(dynamicsNames) {
+		return dynamicsNames[d]
+	}
+	return fmt.Sprintf("Dynamics(%d)", int(d))
+}
+
+// ParseDynamics returns the Dynamics with the given name, as returned by
+// String.
+func ParseDynamics(name string) (Dynamics, error) {
+	for d, n := range dynamicsNames {
+		if n == name {
+			return Dynamics(d), nil
+		}
+	}
+	return Static, fmt.Errorf("unknown dynamics %q (want one of %v)", name, dynamicsNames)
+}
+
+// flattenChooser implements Flatten as a mixture of the initial distribution
+// and a uniform one, choosing uniformly with a probability growing from 0
+// toward 1 over time. This is equivalent to every probability moving linearly
+// toward uniform, without needing to touch every weight.
+type flattenChooser struct {
+	base     Chooser
+	rs       []Ranking
+	halfLife time.Duration
+	start    time.Time
+}
+
+func newFlattenChooser(base Chooser, rs []Ranking, halfLife time.Duration) *flattenChooser {
+	if halfLife <= 0 {
+		halfLife = DefaultFlattenHalfLife
+	}
+	return &flattenChooser{base: base, rs: rs, halfLife: halfLife, start: time.Now()}
+}
+
+func (c *flattenChooser) Choose() Ranking {
+	p := 1 - math.Exp2(-float64(time.Since(c.start))/float64(c.halfLife))
+	if rand.Float64() < p {
+		return c.rs[rand.Intn(len(c.rs))]
+	}
+	return c.base.Choose()
+}
+
+// fenwickChooser implements RichGetRicher, keeping weights in a Fenwick tree
+// (binary indexed tree) so that both choosing an emoji and adding weight to
+// one take O(log n) time.
+type fenwickChooser struct {
+	rs    []Ranking
+	index map[string]int // position in rs by ID
+	gain  float64
+
+	mu   sync.Mutex
+	tree []float64 // 1-based Fenwick tree of weights
+	top  int       // highest power of 2 <= len(rs)
+}
+
+func newFenwickChooser(rs []Ranking, weights []float64, gain float64) (*fenwickChooser, error) {
+	if _, err := checkWeights(rs, weights); err != nil {
+		return nil, err
+	}
+	switch {
+	case gain == 0:
+		gain = 1
+	case !(gain > 0) || math.IsInf(gain, 0):
+		return nil, fmt.Errorf("invalid gain %v", gain)
+	}
+	c := &fenwickChooser{
+		rs:    rs,
+		index: make(map[string]int, len(rs)),
+		gain:  gain,
+		tree:  make([]float64, len(rs)+1),
+		top:   1,
+	}
+	for c.top*2 <= len(rs) {
+		c.top *= 2
+	}
+	for i, r := range rs {
+		c.index[r.ID] = i
+		c.add(i, weights[i])
+	}
+	return c, nil
+}
+
+// add adds w to the weight of rs[i]. The caller must hold c.mu, or have
+// exclusive access.
+func (c *fenwickChooser) add(i int, w float64) {
+	for j := i + 1; j < len(c.tree); j += j & -j {
+		c.tree[j] += w
+	}
+}
+
+func (c *fenwickChooser) Choose() Ranking {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var total float64
+	for j := len(c.tree) - 1; j > 0; j -= j & -j {
+		total += c.tree[j]
+	}
+
+	// with no weight at all (e.g. all zero scores), every emoji is equally
+	// likely, as with NewWeightedChooser
+	if total == 0 {
+		return c.rs[rand.Intn(len(c.rs))]
+	}
+
+	// descend the tree to the first position whose prefix sum exceeds target
+	target := rand.Float64() * total
+	pos := 0
+	for step := c.top; step > 0; step /= 2 {
+		if next := pos + step; next < len(c.tree) && c.tree[next] <= target {
+			pos = next
+			target -= c.tree[next]
+		}
+	}
+	if pos >= len(c.rs) { // only possible through float rounding
+		pos = len(c.rs) - 1
+	}
+	return c.rs[pos]
+}
+
+func (c *fenwickChooser) Observe(emoji Ranking) {
+	i, ok := c.index[emoji.ID]
+	if !ok {
+		return
+	}
+	c.mu.Lock()
+	c.add(i, c.gain)
+	c.mu.Unlock()
+}
@@ -0,0 +1,65 @@
+package fakefeeder
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFenwickChooserZeroWeights(t *testing.T) {
+	rs := []Ranking{{ID: "A"}, {ID: "B"}, {ID: "C"}, {ID: "D"}}
+	c, err := newFenwickChooser(rs, make([]float64, len(rs)), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	counts := make(map[string]int)
+	for i := 0; i < 400; i++ {
+		counts[c.Choose().ID]++
+	}
+	for _, r := range rs {
+		if counts[r.ID] == 0 {
+			t.Errorf("%s never chosen with all zero weights, got %v", r.ID, counts)
+		}
+	}
+}
+
+func TestFenwickChooserObserve(t *testing.T) {
+	rs := []Ranking{{ID: "A"}, {ID: "B"}}
+	c, err := newFenwickChooser(rs, []float64{0, 0}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Observe(rs[1])
+	for i := 0; i < 100; i++ {
+		if got := c.Choose().ID; got != "B" {
+			t.Fatalf("chose %s, want only B to have weight", got)
+		}
+	}
+}
+
+func TestFenwickChooserInvalidGain(t *testing.T) {
+	rs := []Ranking{{ID: "A"}}
+	for _, gain := range []float64{-1, math.NaN(), math.Inf(1)} {
+		if _, err := newFenwickChooser(rs, []float64{1}, gain); err == nil {
+			t.Errorf("gain %v: want error", gain)
+		}
+	}
+}
+
+func TestParseDynamics(t *testing.T) {
+	for _, d := range []Dynamics{Static, RichGetRicher, Flatten} {
+		got, err := ParseDynamics(d.String())
+		if err != nil || got != d {
+			t.Errorf("ParseDynamics(%q) = %v, %v", d.String(), got, err)
+		}
+	}
+	if _, err := ParseDynamics("bogus"); err == nil {
+		t.Error("ParseDynamics(bogus): want error")
+	}
+}
+
+func TestFlattenChooserDefaultHalfLife(t *testing.T) {
+	c := newFlattenChooser(uniformChooser{{ID: "A"}}, []Ranking{{ID: "A"}}, 0)
+	if c.halfLife != DefaultFlattenHalfLife {
+		t.Errorf("half-life = %v, want %v", c.halfLife, DefaultFlattenHalfLife)
+	}
+}
@@ -0,0 +1,181 @@
+package fakefeeder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// SeedError reports a failure seeding the initial data into a sink. It is
+// fatal to NewFeeder.
+type SeedError struct {
+	What string // the data being seeded, e.g. "scores" or "tweets"
+	Err  error
+}
+
+func (e *SeedError) Error() string {
+	return fmt.Sprintf("could not seed initial %s: %v", e.What, e.Err)
+}
+
+func (e *SeedError) Unwrap() error { return e.Err }
+
+// SeedFailure identifies a single emoji for which seeding failed.
+type SeedFailure struct {
+	ID  string
+	Err error
+}
+
+// SeedFailures is returned by a Sink when seeding failed for some emoji, but
+// others were seeded successfully. A Feeder will return it wrapped in a
+// SeedError.
+type SeedFailures []SeedFailure
+
+// maxSeedFailuresShown limits the emoji listed in SeedFailures.Error().
+const maxSeedFailuresShown = 5
+
+// Error lists each emoji which failed once, with its first error.
+func (fs SeedFailures) Error() string {
+	ids := fs.IDs()
+	var b strings.Builder
+	fmt.Fprintf(&b, "failed for %d emoji: ", len(ids))
+	for i, id := range ids {
+		if i == maxSeedFailuresShown {
+			fmt.Fprintf(&b, ", and %d more", len(ids)-i)
+			break
+		}
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "%s (%v)", id, fs.firstErr(id))
+	}
+	return b.String()
+}
+
+// firstErr returns the first error for emoji id.
+func (fs SeedFailures) firstErr(id string) error {
+	for _, f := range fs {
+		if f.ID == id {
+			return f.Err
+		}
+	}
+	return nil
+}
+
+// IDs returns the unique IDs of the emoji which failed, in order.
+func (fs SeedFailures) IDs() []string {
+	seen := make(map[string]bool, len(fs))
+	ids := make([]string, 0, len(fs))
+	for _, f := range fs {
+		if !seen[f.ID] {
+			seen[f.ID] = true
+			ids = append(ids, f.ID)
+		}
+	}
+	return ids
+}
+
+// ScriptLoadError reports that redis rejected the Lua update script. This is
+// not expected to resolve by retrying.
+type ScriptLoadError struct {
+	Err error
+}
+
+func (e *ScriptLoadError) Error() string {
+	return fmt.Sprintf("could not load Lua update script: %v", e.Err)
+}
+
+func (e *ScriptLoadError) Unwrap() error { return e.Err }
+
+// ScriptError reports an error returned by redis while running the update
+// script for a single update. Subsequent updates may still succeed.
+type ScriptError struct {
+	Err error
+}
+
+func (e *ScriptError) Error() string {
+	return fmt.Sprintf("update script failed: %v", e.Err)
+}
+
+func (e *ScriptError) Unwrap() error { return e.Err }
+
+// TransportError reports a failure communicating with the sink, such as a
+// network error or timeout. These are transient: a Feeder will reconnect once
+// the sink is available again.
+type TransportError struct {
+	Err error
+}
+
+func (e *TransportError) Error() string {
+	return fmt.Sprintf("could not reach sink: %v", e.Err)
+}
+
+func (e *TransportError) Unwrap() error { return e.Err }
+
+// IsTransient reports whether err is expected to resolve on its own, such that
+// it is worth continuing to send updates.
+func IsTransient(err error) bool {
+	var (
+		transportErr *TransportError
+		scriptErr    *ScriptError
+	)
+	return errors.Is(err, ErrSinkDown) ||
+		errors.As(err, &transportErr) ||
+		errors.As(err, &scriptErr)
+}
+
+// ErrorClass returns a short, stable label describing the kind of failure err
+// represents, suitable for use as a structured logging field or metric label.
+func ErrorClass(err error) string {
+	var (
+		seedErr       *SeedError
+		scriptLoadErr *ScriptLoadError
+		scriptErr     *ScriptError
+		redisErr      redis.Error
+		netErr        net.Error
+	)
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return "canceled"
+	case errors.Is(err, ErrSinkDown):
+		return "down"
+	case errors.As(err, &seedErr):
+		return "seed"
+	case errors.As(err, &scriptLoadErr):
+		return "script_load"
+	case errors.As(err, &scriptErr):
+		return "script"
+	case errors.As(err, &redisErr):
+		return "redis"
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return "timeout"
+	case errors.As(err, &netErr):
+		return "network"
+	default:
+		return "other"
+	}
+}
+
+// redisError wraps an error from redis in the appropriate error type: redis
+// error replies with wrapReply, and anything else as a TransportError.
+func redisError(err error, wrapReply func(error) error) error {
+	var redisErr redis.Error
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &redisErr):
+		return wrapReply(err)
+	default:
+		return &TransportError{Err: err}
+	}
+}
+
+// transportError wraps any non-reply error from redis as a TransportError.
+func transportError(err error) error {
+	return redisError(err, func(err error) error { return err })
+}
@@ -0,0 +1,56 @@
+package fakefeeder
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestSeedFailuresError(t *testing.T) {
+	lpush, ltrim := errors.New("LPUSH failed"), errors.New("LTRIM failed")
+	many := make(SeedFailures, 0, 14)
+	for i := 0; i < 7; i++ {
+		id := fmt.Sprintf("1F60%d", i)
+		many = append(many, SeedFailure{ID: id, Err: lpush}, SeedFailure{ID: id, Err: ltrim})
+	}
+
+	for _, tc := range []struct {
+		name string
+		fs   SeedFailures
+		want string
+	}{
+		{
+			name: "single",
+			fs:   SeedFailures{{ID: "1F602", Err: lpush}},
+			want: "failed for 1 emoji: 1F602 (LPUSH failed)",
+		},
+		{
+			// both commands seeding the tweets of an emoji failed
+			name: "repeated emoji",
+			fs: SeedFailures{
+				{ID: "1F602", Err: lpush}, {ID: "1F602", Err: ltrim},
+				{ID: "2764", Err: lpush}, {ID: "2764", Err: ltrim},
+			},
+			want: "failed for 2 emoji: 1F602 (LPUSH failed), 2764 (LPUSH failed)",
+		},
+		{
+			name: "more than shown",
+			fs:   many,
+			want: "failed for 7 emoji: 1F600 (LPUSH failed), 1F601 (LPUSH failed), " +
+				"1F602 (LPUSH failed), 1F603 (LPUSH failed), 1F604 (LPUSH failed), and 2 more",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.fs.Error(); got != tc.want {
+				t.Errorf("Error() = %q\nwant %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSeedFailuresIDs(t *testing.T) {
+	fs := SeedFailures{{ID: "B"}, {ID: "A"}, {ID: "B"}, {ID: "C"}, {ID: "A"}}
+	if got, want := fmt.Sprint(fs.IDs()), "[B A C]"; got != want {
+		t.Errorf("IDs() = %s, want %s", got, want)
+	}
+}
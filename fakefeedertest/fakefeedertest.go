@@ -0,0 +1,390 @@
+// Package fakefeedertest provides an in-memory stand-in for the redis written
+// by a Feeder, so that the Feeder, and consumers of its data, can be tested
+// without a live redis.
+package fakefeedertest
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	fakefeeder "github.com/emojitracker/emojitrack-fakefeeder"
+)
+
+// Message is a single message published to a pub/sub channel.
+type Message struct {
+	Channel string
+	Data    []byte
+}
+
+// Sink is an in-memory fakefeeder.FaultSink, recording the same data that a
+// fakefeeder.RedisSink writes to redis with the same semantics: scores,
+// recent tweets for each emoji (newest first, trimmed to the history depth),
+// and messages published to the channels named by fakefeeder.ScoreUpdatesChannel
+// and fakefeeder.TweetUpdatesPrefix.
+//
+// A Sink is safe for concurrent use by multiple goroutines.
+type Sink struct {
+	mu        sync.Mutex
+	changed   *sync.Cond // broadcast on every update
+	depth     int
+	scores    map[string]int64
+	tweets    map[string][][]byte
+	messages  []Message
+	updates   int
+	drops     int
+	err       error
+	prepared  int
+	listeners []chan<- Message
+}
+
+var _ fakefeeder.FaultSink = (*Sink)(nil)
+
+// NewSink returns an empty Sink.
+func NewSink() *Sink {
+	s := &Sink{
+		depth:  fakefeeder.DefaultHistoryDepth,
+		scores: make(map[string]int64),
+		tweets: make(map[string][][]byte),
+	}
+	s.changed = sync.NewCond(&s.mu)
+	return s
+}
+
+// NewFeeder returns a Feeder seeded with rs and writing to a new Sink, failing
+// t immediately if it cannot be set up.
+func NewFeeder(t testing.TB, rs []fakefeeder.Ranking, opts fakefeeder.Options) (*fakefeeder.Feeder, *Sink) {
+	t.Helper()
+	s := NewSink()
+	f, err := fakefeeder.NewFeeder(s, rs, opts)
+	if err != nil {
+		t.Fatalf("could not set up feeder: %v", err)
+	}
+	return f, s
+}
+
+// SetError makes every subsequent call to a Sink method return err (such as a
+// *fakefeeder.TransportError, to simulate redis being down), until it is
+// called again with nil.
+func (s *Sink) SetError(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
+
+// Prepare implements fakefeeder.Sink.
+func (s *Sink) Prepare(cfg fakefeeder.SinkConfig) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.depth = cfg.HistoryDepth
+	s.prepared++
+	return nil
+}
+
+// Seeded implements fakefeeder.Sink.
+func (s *Sink) Seeded() (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.scores) > 0, s.err
+}
+
+// SeedScores implements fakefeeder.Sink.
+func (s *Sink) SeedScores(rs []fakefeeder.Ranking) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	for _, r := range rs {
+		s.scores[r.ID] = r.Score
+	}
+	return nil
+}
+
+// SeedTweets implements fakefeeder.Sink.
+func (s *Sink) SeedTweets(hs []fakefeeder.TweetHistory) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	for _, h := range hs {
+		for _, t := range h.Tweets {
+			s.pushTweet(h.ID, t)
+		}
+	}
+	return nil
+}
+
+// Clear implements fakefeeder.Sink. As with redis, all scores are removed,
+// not only those for ids.
+func (s *Sink) Clear(ids []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.scores = make(map[string]int64)
+	for _, id := range ids {
+		delete(s.tweets, id)
+	}
+	return nil
+}
+
+// Update implements fakefeeder.Sink, in the same way as the redis update
+// script.
+func (s *Sink) Update(id string, tweet []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.scores[id]++
+	s.publish(fakefeeder.ScoreUpdatesChannel, []byte(id))
+	s.pushTweet(id, tweet)
+	s.publish(fakefeeder.TweetUpdatesPrefix+id, tweet)
+	s.updates++
+	s.changed.Broadcast()
+	return nil
+}
+
+// Increment implements fakefeeder.FaultSink.
+func (s *Sink) Increment(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.scores[id]++
+	return nil
+}
+
+// Publish implements fakefeeder.FaultSink.
+func (s *Sink) Publish(id string, tweet []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.publish(fakefeeder.ScoreUpdatesChannel, []byte(id))
+	s.publish(fakefeeder.TweetUpdatesPrefix+id, tweet)
+	return nil
+}
+
+// DropConnections implements fakefeeder.FaultSink by counting the call, see
+// Drops.
+func (s *Sink) DropConnections() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.drops++
+	return nil
+}
+
+// pushTweet adds tweet to the head of the history for id, trimmed to the
+// history depth. The caller must hold s.mu.
+func (s *Sink) pushTweet(id string, tweet []byte) {
+	list := append([][]byte{tweet}, s.tweets[id]...)
+	if len(list) > s.depth {
+		list = list[:s.depth]
+	}
+	s.tweets[id] = list
+}
+
+// publish records a message, and sends it to any listeners. The caller must
+// hold s.mu.
+func (s *Sink) publish(channel string, data []byte) {
+	m := Message{Channel: channel, Data: data}
+	s.messages = append(s.messages, m)
+	for _, l := range s.listeners {
+		select {
+		case l <- m:
+		default: // like redis, slow subscribers miss messages
+		}
+	}
+}
+
+// Listen sends every message published from now on to c, without blocking:
+// messages are dropped if c is not ready to receive them.
+func (s *Sink) Listen(c chan<- Message) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listeners = append(s.listeners, c)
+}
+
+// Score returns the current score for emoji id, and whether it has one.
+func (s *Sink) Score(id string) (int64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	score, ok := s.scores[id]
+	return score, ok
+}
+
+// Scores returns a copy of all current scores by emoji ID.
+func (s *Sink) Scores() map[string]int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	scores := make(map[string]int64, len(s.scores))
+	for id, score := range s.scores {
+		scores[id] = score
+	}
+	return scores
+}
+
+// Tweets returns the recent tweets stored for emoji id, newest first.
+func (s *Sink) Tweets(id string) [][]byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([][]byte(nil), s.tweets[id]...)
+}
+
+// DecodedTweets is like Tweets, but decodes each tweet.
+func (s *Sink) DecodedTweets(id string) ([]fakefeeder.EnsmallenedTweet, error) {
+	var results []fakefeeder.EnsmallenedTweet
+	for _, b := range s.Tweets(id) {
+		var t fakefeeder.EnsmallenedTweet
+		if err := json.Unmarshal(b, &t); err != nil {
+			return nil, err
+		}
+		results = append(results, t)
+	}
+	return results, nil
+}
+
+// Messages returns all messages published to channel, oldest first. An empty
+// channel returns the messages published to every channel.
+func (s *Sink) Messages(channel string) []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var results []Message
+	for _, m := range s.messages {
+		if channel == "" || m.Channel == channel {
+			results = append(results, m)
+		}
+	}
+	return results
+}
+
+// Updates returns the number of successful calls to Update.
+func (s *Sink) Updates() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.updates
+}
+
+// Drops returns the number of calls to DropConnections.
+func (s *Sink) Drops() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.drops
+}
+
+// Prepared returns the number of successful calls to Prepare, which is more
+// than 1 once a Feeder has reconnected.
+func (s *Sink) Prepared() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.prepared
+}
+
+// WaitForUpdates blocks until at least n updates have been made in total,
+// failing t if that takes longer than timeout.
+func (s *Sink) WaitForUpdates(t testing.TB, n int, timeout time.Duration) {
+	t.Helper()
+	timer := time.AfterFunc(timeout, func() {
+		s.mu.Lock()
+		s.changed.Broadcast()
+		s.mu.Unlock()
+	})
+	defer timer.Stop()
+	deadline := time.Now().Add(timeout)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for s.updates < n {
+		if !time.Now().Before(deadline) {
+			t.Fatalf("timed out after %v waiting for %d updates, got %d", timeout, n, s.updates)
+		}
+		s.changed.Wait()
+	}
+}
+
+// AssertScore fails t unless the score for emoji id is want.
+func (s *Sink) AssertScore(t testing.TB, id string, want int64) {
+	t.Helper()
+	got, ok := s.Score(id)
+	switch {
+	case !ok:
+		t.Errorf("no score for %s, want %d", id, want)
+	case got != want:
+		t.Errorf("score for %s = %d, want %d", id, got, want)
+	}
+}
+
+// AssertTweetCount fails t unless exactly want tweets are stored for emoji id.
+func (s *Sink) AssertTweetCount(t testing.TB, id string, want int) {
+	t.Helper()
+	if got := len(s.Tweets(id)); got != want {
+		t.Errorf("%d tweets stored for %s, want %d", got, id, want)
+	}
+}
+
+// AssertPublished fails t unless exactly want messages have been published to
+// channel (or to every channel, if empty).
+func (s *Sink) AssertPublished(t testing.TB, channel string, want int) {
+	t.Helper()
+	if got := len(s.Messages(channel)); got != want {
+		t.Errorf("%d messages published to %q, want %d", got, channel, want)
+	}
+}
+
+// AssertConsistent fails t unless the stored data is consistent with every
+// update having been applied in full: each emoji's latest stored tweet must be
+// the last one published on its tweet channel, and the number of score updates
+// published for each emoji must equal its increase in score from base (e.g.
+// its seed scores).
+func (s *Sink) AssertConsistent(t testing.TB, base map[string]int64) {
+	t.Helper()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	published := make(map[string]int64)
+	latest := make(map[string][]byte)
+	for _, m := range s.messages {
+		switch {
+		case m.Channel == fakefeeder.ScoreUpdatesChannel:
+			published[string(m.Data)]++
+		case strings.HasPrefix(m.Channel, fakefeeder.TweetUpdatesPrefix):
+			latest[strings.TrimPrefix(m.Channel, fakefeeder.TweetUpdatesPrefix)] = m.Data
+		}
+	}
+	// check every emoji with a score or a base score too, since a score can
+	// increase without anything being published (e.g. Sink.Increment)
+	ids := make(map[string]bool)
+	for id := range published {
+		ids[id] = true
+	}
+	for id := range s.scores {
+		ids[id] = true
+	}
+	for id := range base {
+		ids[id] = true
+	}
+	for id := range ids {
+		if got, n := s.scores[id]-base[id], published[id]; got != n {
+			t.Errorf("score for %s increased by %d, but %d updates were published", id, got, n)
+		}
+	}
+	for id, tweet := range latest {
+		if list := s.tweets[id]; len(list) == 0 || string(list[0]) != string(tweet) {
+			t.Errorf("latest tweet stored for %s is not the last one published", id)
+		}
+	}
+}
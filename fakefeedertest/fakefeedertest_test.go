@@ -0,0 +1,175 @@
+package fakefeedertest
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	fakefeeder "github.com/emojitracker/emojitrack-fakefeeder"
+)
+
+func TestSinkUpdateTrimsToDepth(t *testing.T) {
+	s := NewSink()
+	if err := s.Prepare(fakefeeder.SinkConfig{HistoryDepth: 3}); err != nil {
+		t.Fatal(err)
+	}
+	for i := 1; i <= 5; i++ {
+		if err := s.Update("1F525", []byte(fmt.Sprint(i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s.AssertScore(t, "1F525", 5)
+	s.AssertTweetCount(t, "1F525", 3)
+	got := s.Tweets("1F525")
+	for i, want := range []string{"5", "4", "3"} { // newest first
+		if string(got[i]) != want {
+			t.Errorf("tweet %d = %q, want %q", i, got[i], want)
+		}
+	}
+}
+
+func TestSinkUpdatePublishOrder(t *testing.T) {
+	s := NewSink()
+	s.SeedScores([]fakefeeder.Ranking{{ID: "1F602", Score: 10}})
+	s.Update("1F602", []byte("a"))
+	s.Update("2764", []byte("b"))
+
+	// as in the update script: the score update, then the tweet update
+	want := []Message{
+		{fakefeeder.ScoreUpdatesChannel, []byte("1F602")},
+		{fakefeeder.TweetUpdatesPrefix + "1F602", []byte("a")},
+		{fakefeeder.ScoreUpdatesChannel, []byte("2764")},
+		{fakefeeder.TweetUpdatesPrefix + "2764", []byte("b")},
+	}
+	got := s.Messages("")
+	if len(got) != len(want) {
+		t.Fatalf("got %d messages, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i].Channel != want[i].Channel || string(got[i].Data) != string(want[i].Data) {
+			t.Errorf("message %d = %s %q, want %s %q", i, got[i].Channel, got[i].Data, want[i].Channel, want[i].Data)
+		}
+	}
+	s.AssertScore(t, "1F602", 11)
+	s.AssertScore(t, "2764", 1) // like ZINCRBY, a missing score starts at 0
+	s.AssertPublished(t, fakefeeder.ScoreUpdatesChannel, 2)
+	s.AssertConsistent(t, map[string]int64{"1F602": 10})
+}
+
+func TestSinkSeedAndClear(t *testing.T) {
+	s := NewSink()
+	if seeded, _ := s.Seeded(); seeded {
+		t.Fatal("new sink reports seeded")
+	}
+	s.SeedScores([]fakefeeder.Ranking{{ID: "A", Score: 1}, {ID: "B", Score: 2}})
+	s.SeedTweets([]fakefeeder.TweetHistory{{ID: "A", Tweets: [][]byte{[]byte("1"), []byte("2")}}})
+	if seeded, _ := s.Seeded(); !seeded {
+		t.Fatal("seeded sink reports unseeded")
+	}
+	s.AssertTweetCount(t, "A", 2)
+	if got := s.Tweets("A"); string(got[0]) != "2" {
+		t.Errorf("newest seeded tweet = %q, want the last pushed", got[0])
+	}
+
+	s.Clear([]string{"A"})
+	if len(s.Scores()) != 0 {
+		t.Errorf("scores remain after Clear: %v", s.Scores())
+	}
+	s.AssertTweetCount(t, "A", 0)
+}
+
+func TestSinkFaultOperations(t *testing.T) {
+	s := NewSink()
+	s.Increment("A")
+	s.Publish("B", []byte("t"))
+	s.DropConnections()
+
+	s.AssertScore(t, "A", 1)
+	if _, ok := s.Score("B"); ok {
+		t.Error("Publish changed a score")
+	}
+	s.AssertPublished(t, fakefeeder.TweetUpdatesPrefix+"B", 1)
+	s.AssertTweetCount(t, "B", 0)
+	if s.Drops() != 1 {
+		t.Errorf("Drops() = %d, want 1", s.Drops())
+	}
+	if s.Updates() != 0 {
+		t.Errorf("Updates() = %d, want 0", s.Updates())
+	}
+}
+
+func TestSinkSetError(t *testing.T) {
+	s := NewSink()
+	down := errors.New("down")
+	s.SetError(down)
+	if err := s.Update("A", nil); err != down {
+		t.Errorf("Update() = %v, want %v", err, down)
+	}
+	if _, err := s.Seeded(); err != down {
+		t.Errorf("Seeded() = %v, want %v", err, down)
+	}
+	s.SetError(nil)
+	if err := s.Update("A", nil); err != nil {
+		t.Errorf("Update() after clearing error = %v", err)
+	}
+}
+
+func TestSinkListen(t *testing.T) {
+	s := NewSink()
+	c := make(chan Message, 2)
+	s.Listen(c)
+	s.Update("A", []byte("t"))
+	if m := <-c; m.Channel != fakefeeder.ScoreUpdatesChannel {
+		t.Errorf("first message on %s, want %s", m.Channel, fakefeeder.ScoreUpdatesChannel)
+	}
+	if m := <-c; m.Channel != fakefeeder.TweetUpdatesPrefix+"A" {
+		t.Errorf("second message on %s", m.Channel)
+	}
+
+	// a full listener misses messages rather than blocking the sink
+	s.Update("A", []byte("t"))
+	s.Update("A", []byte("t"))
+	if s.Updates() != 3 {
+		t.Errorf("Updates() = %d, want 3", s.Updates())
+	}
+}
+
+// recorder is a testing.TB which records failures rather than failing.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+// A score increased without anything being published, as by the increment
+// only fault, is inconsistent.
+func TestSinkAssertConsistentBareIncrement(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		base map[string]int64
+	}{
+		{"unseeded", nil},
+		{"seeded", map[string]int64{"X": 5}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := NewSink()
+			if tc.base != nil {
+				s.SeedScores([]fakefeeder.Ranking{{ID: "X", Score: tc.base["X"]}})
+			}
+			s.Update("Y", []byte("t"))
+			s.Increment("X")
+
+			r := &recorder{TB: t}
+			s.AssertConsistent(r, tc.base)
+			if len(r.errors) != 1 {
+				t.Errorf("AssertConsistent after a bare Increment reported %q, want one error for X", r.errors)
+			}
+		})
+	}
+}
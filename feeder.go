@@ -2,12 +2,12 @@ package fakefeeder
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"math/rand"
+	"log/slog"
+	"sync"
+	"sync/atomic"
 	"time"
-
-	"github.com/gomodule/redigo/redis"
-	"github.com/mroth/weightedrand"
 )
 
 // Feeder will generate probable random data to a redis instance to emulate the
@@ -16,213 +16,257 @@ import (
 //
 // It is primarily useful for feeding data to emulate realtime behavior hacking
 // on the other components of Emojitracker within a docker network.
+//
+// A Feeder is safe for concurrent use by multiple goroutines, once Logger (if
+// any) has been set.
 type Feeder struct {
-	rp            *redis.Pool
-	seed          []Ranking
-	chooseFunc    func() Ranking
-	VerboseLogger logger // override to enable verbose update logging
+	sink    Sink
+	seed    []Ranking
+	opts    Options
+	chooser atomic.Pointer[activeChooser]
+	health  healthState
+	Logger  *slog.Logger // override to enable logging, updates are logged at debug level
 }
 
-type logger interface {
-	Printf(string, ...interface{})
-}
-
-// NewFeeder generates a Feeder utilizing a configured redis.Pool p, using seed
-// data. The weight parameter determines whether the updates it generates will
-// be probablistically weighted based on past scores rather than uniform random
-// distribution.
+// NewFeeder generates a Feeder writing to Sink s (usually a RedisSink), using
+// seed data, configured by opts.
 //
-// Once NewFeeder is initialized, it will automatically seed the initial data,
-// but note it will not start sending realtime updates until Start() is invoked
-// or manual updates are sent via the Update() command.
+// Once NewFeeder is initialized, it will automatically seed the initial data
+// (unless opts.SkipSeed is set), but note it will not start sending realtime
+// updates until Start() is invoked or manual updates are sent via the Update()
+// command.
 //
-// NewFeeder will return an error if it was unable to properly seed the redis
-// instance in any fashion.
-func NewFeeder(p *redis.Pool, seed []Ranking, weight bool) (*Feeder, error) {
-	cf, err := buildChooseFunc(seed, weight)
-	if err != nil {
-		return nil, err
+// NewFeeder will return an error if it was unable to properly prepare or seed
+// the sink in any fashion, such as a *SeedError, *ScriptLoadError or
+// *TransportError.
+func NewFeeder(s Sink, seed []Ranking, opts Options) (*Feeder, error) {
+	f := &Feeder{
+		sink: s,
+		seed: seed,
+		opts: opts,
 	}
-	f := Feeder{
-		rp:         p,
-		seed:       seed,
-		chooseFunc: cf,
+	if err := f.SetRankings(seed); err != nil {
+		return nil, err
 	}
 
-	err = f.init()
-	return &f, err
-}
-
-func (f *Feeder) init() error {
-	c := f.rp.Get()
-	defer c.Close()
-
-	err := f.seedScores(c)
-	if err != nil {
-		return fmt.Errorf("could not seed initial scores: %w", err)
-	}
-	err = f.seedTweets(c)
-	if err != nil {
-		return fmt.Errorf("could not seed initial tweets: %w", err)
+	if err := f.sink.Prepare(opts.sinkConfig()); err != nil {
+		return nil, err
 	}
-	err = updateScript.Load(c)
-	if err != nil {
-		return fmt.Errorf("could not load Lua update script: %w", err)
+	if !opts.SkipSeed {
+		if err := f.init(); err != nil {
+			return nil, err
+		}
 	}
-
-	return nil
+	return f, nil
 }
 
-// constants of redis commands to avoid potential runtime errors from typos :-)
-const (
-	rEXEC  = "EXEC"
-	rLPUSH = "LPUSH"
-	rMULTI = "MULTI"
-	rZADD  = "ZADD"
-
-	rScoreKey       = "emojitrack_score"
-	rTweetKeyPrefix = "emojitrack_tweets_"
-)
-
-// seedScores sets all scores in redis.Conn c to match snapshot data.
-func (f *Feeder) seedScores(c redis.Conn) error {
-	c.Send(rMULTI)
-	for _, r := range f.seed {
-		err := c.Send(rZADD, rScoreKey, r.Score, r.ID)
-		if err != nil {
-			return err
-		}
-	}
-	_, err := c.Do(rEXEC)
-	return err
+// activeChooser is the Chooser used by a Feeder, along with the rankings it
+// was built from.
+type activeChooser struct {
+	Chooser
+	rankings []Ranking
 }
 
-// seedTweets generate 10 initial random tweets for each existing emoji, such
-// that initial buffer for historical window is filled.
-func (f *Feeder) seedTweets(c redis.Conn) error {
-	c.Send(rMULTI)
-	for _, r := range f.seed {
-		for i := 0; i < 10; i++ {
-			t := randomTweetForEmoji(r)
-			tKey := rTweetKeyPrefix + r.ID
-			err := c.Send(rLPUSH, tKey, t.MustEncode())
-			if err != nil {
-				return err
-			}
-		}
+// SetRankings replaces the rankings which Update chooses emoji from, building
+// a new Chooser as configured by the Options, e.g. to follow a live rankings
+// endpoint. The change is atomic, so updates in progress are neither paused
+// nor see a partial state. Any weight accumulated under Options.Dynamics
+// starts over from rs.
+//
+// The data seeded by Reset is unaffected, and remains the original seed.
+func (f *Feeder) SetRankings(rs []Ranking) error {
+	c, err := newChooser(rs, f.opts)
+	if err != nil {
+		return err
 	}
-	_, err := c.Do(rEXEC)
-	return err
+	f.chooser.Store(&activeChooser{Chooser: c, rankings: rs})
+	return nil
 }
 
-func buildChooseFunc(seed []Ranking, weighted bool) (func() Ranking, error) {
-	// non-weighted, simple random choice
-	if !weighted {
-		cf := func() Ranking {
-			return seed[rand.Intn(len(seed))]
-		}
-		return cf, nil
+// Reset restores the sink to the initial seed state, discarding the scores and
+// tweets accumulated from any updates sent since. Weights changed under
+// Options.Dynamics are restored to their initial values as well.
+func (f *Feeder) Reset() error {
+	ids := make([]string, 0, len(f.seed))
+	for _, r := range f.seed {
+		ids = append(ids, r.ID)
 	}
-
-	// weighted random distribution (using github.com/mroth/weightedrand)
-	choices := make([]weightedrand.Choice, 0, len(seed))
-	for _, r := range seed {
-		c := weightedrand.Choice{Item: r, Weight: uint(r.Score)}
-		choices = append(choices, c)
+	if err := f.sink.Clear(ids); err != nil {
+		return fmt.Errorf("could not clear existing data: %w", err)
 	}
-	chooser, err := weightedrand.NewChooser(choices...)
-	if err != nil {
-		return nil, err
-	}
-	cf := func() Ranking {
-		return chooser.Pick().(Ranking)
+	if err := f.SetRankings(f.chooser.Load().rankings); err != nil {
+		return err
 	}
-	return cf, nil
+	return f.init()
 }
 
-// Update sends a single random update to the configured redis instance.
+// Update sends a single random update to the configured sink.
 func (f *Feeder) Update() error {
-	c := f.rp.Get()
-	defer c.Close()
+	return f.UpdateEmoji(f.chooser.Load().Choose())
+}
 
-	emoji := f.chooseFunc()
-	tweet := randomTweetForEmoji(emoji)
-	payload := tweet.MustEncode()
-	if err := updateScript.SendHash(c, emoji.ID, payload); err != nil {
+// UpdateEmoji sends a single update for the specified emoji to the configured
+// sink, regardless of its probability of being chosen by Update.
+//
+// If the sink is Down, the update is not attempted and ErrSinkDown is returned,
+// unless a reconnection attempt is due.
+func (f *Feeder) UpdateEmoji(emoji Ranking) error {
+	if err := f.checkHealth(); err != nil {
 		return err
 	}
-	c.Flush()
-	if f.VerboseLogger != nil {
-		f.VerboseLogger.Printf("sent fake update for %v", emoji)
+	start := time.Now()
+	tweet := randomTweetForEmoji(emoji)
+	err := f.sink.Update(emoji.ID, tweet.MustEncode())
+	if o, ok := f.chooser.Load().Chooser.(Observer); ok && err == nil {
+		o.Observe(emoji)
 	}
-	_, err := c.Receive() // blocks for response
+	f.recordResult(err)
+	f.logUpdate(emoji, tweet, time.Since(start), err)
 	return err
 }
 
+// logUpdate logs the outcome of a single update at debug level.
+func (f *Feeder) logUpdate(emoji Ranking, tweet EnsmallenedTweet, latency time.Duration, err error) {
+	if f.Logger == nil || !f.Logger.Enabled(context.Background(), slog.LevelDebug) {
+		return
+	}
+	attrs := []slog.Attr{
+		slog.String("emoji_id", emoji.ID),
+		slog.String("emoji", emoji.Char),
+		slog.String("tweet_id", tweet.ID),
+		slog.Duration("latency", latency),
+	}
+	if err != nil {
+		attrs = append(attrs,
+			slog.String("error", err.Error()),
+			slog.String("error_class", ErrorClass(err)),
+		)
+		f.Logger.LogAttrs(context.Background(), slog.LevelDebug, "fake update failed", attrs...)
+		return
+	}
+	f.Logger.LogAttrs(context.Background(), slog.LevelDebug, "sent fake update", attrs...)
+}
+
 // Start begins a background goroutine which calls f.Update() every
 // time.Duration d. If the provided context is cancelled for any reason, it will
 // safely cleanup and exit.
 //
-// The returned error chan will report any errors occuring during update. It has
-// a minor buffer to allow a small grace period for consumption, but note that
-// by design it will drop new errors on the floor if they are not being
-// consumed, in order to allow the error chan to be safely ignored (e.g. no
-// manual draining needed) without blocking updates.
-func (f *Feeder) Start(ctx context.Context, d time.Duration) <-chan error {
-	errC := make(chan error, 8)
+// The returned Run reports any errors occuring during update via Errors(). See
+// Run for details of its error handling.
+func (f *Feeder) Start(ctx context.Context, d time.Duration) *Run {
+	return f.StartWorkers(ctx, d, 1)
+}
+
+// StartWorkers is like Start, but fans updates out across n concurrent worker
+// goroutines which share the overall rate of one update every d. Each worker
+// sends an update every n*d, staggered so that updates remain evenly spaced.
+//
+// Additional workers are useful when a single update round-trip to redis takes
+// longer than d, which would otherwise cap the effective rate.
+func (f *Feeder) StartWorkers(ctx context.Context, d time.Duration, n int) *Run {
+	if n < 1 {
+		n = 1
+	}
+	r := &Run{
+		errC: make(chan error, 8),
+		done: make(chan struct{}),
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(offset time.Duration) {
+			defer wg.Done()
+			f.work(ctx, offset, d*time.Duration(n), r)
+		}(d * time.Duration(i))
+	}
 	go func() {
-		ticker := time.NewTicker(d)
-		defer ticker.Stop()
-		defer close(errC)
-
-		for {
-			select {
-			case <-ctx.Done():
-				errC <- ctx.Err()
-				return
-			case <-ticker.C:
-				if err := f.Update(); err != nil {
-					// In this use case, dropping error on the floor is the
-					// desired behavior when the reader gets behind, because the
-					// updates are periodic.
-					select {
-					case errC <- err:
-					default:
-					}
-				}
-			}
-		}
+		wg.Wait()
+		r.err = ctx.Err()
+		close(r.errC)
+		close(r.done)
 	}()
-	return errC
+	return r
 }
 
-// This is the exact same update script used in emojitrack-feeder.
-var updateScript = redis.NewScript(0, `
--- Updates the server whenever a new emoji is seen in a tweet
---
--- Putting this in a script enables us to save some bandwidth by not
--- transmitting any redundant data to the server, as we can calculate the
--- appropriate key names there and re-use data that goes to multiple
--- destinations.
+// work calls f.Update() every period after an initial delay of offset, until
+// ctx is cancelled.
+func (f *Feeder) work(ctx context.Context, offset, period time.Duration, r *Run) {
+	if offset > 0 {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(offset):
+		}
+	}
+
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := f.Update(); err != nil && !errors.Is(err, ErrSinkDown) {
+				r.report(err)
+			}
+		}
+	}
+}
 
-local uid      = ARGV[1]   -- unified codepoint ID
-local tinyjson = ARGV[2]   -- json blob representing the ensmallened tweet
+// Run is a handle to the background updates started by Feeder.Start.
+//
+// Errors occuring during update are reported via Errors(). The channel has a
+// minor buffer to allow a small grace period for consumption, but note that by
+// design new errors are dropped on the floor if they are not being consumed, in
+// order to allow the channel to be safely ignored (e.g. no manual draining
+// needed) without blocking updates. The number of errors dropped is available
+// from Dropped(). ErrSinkDown is not reported for updates skipped while the
+// sink is down; use Feeder.Health() and the Logger to monitor reconnection
+// instead.
+type Run struct {
+	errC    chan error
+	done    chan struct{}
+	err     error
+	dropped atomic.Uint64
+}
 
--- increment the score in a sorted set
-redis.call('ZINCRBY', 'emojitrack_score', 1, uid)
+// Errors returns a channel reporting errors occuring during update, which is
+// closed once all updates have stopped.
+func (r *Run) Errors() <-chan error {
+	return r.errC
+}
 
--- stream the fact that the score was updated
-redis.call('PUBLISH', 'stream.score_updates', uid)
+// Wait blocks until all updates have stopped, and returns Err().
+func (r *Run) Wait() error {
+	<-r.done
+	return r.err
+}
 
--- for each emoji char, store the most recent 10 tweets in a list
-local tweet_details_key = "emojitrack_tweets_" .. uid
-redis.call('LPUSH', tweet_details_key, tinyjson)
-redis.call('LTRIM', tweet_details_key, 0, 9)
+// Err returns nil while updates are running. Once they have stopped, it
+// returns the reason, i.e. the error from the context passed to Start.
+func (r *Run) Err() error {
+	select {
+	case <-r.done:
+		return r.err
+	default:
+		return nil
+	}
+}
 
--- also stream all tweet updates to named streams by char
-local stream_details_key = "stream.tweet_updates." .. uid
-redis.call('PUBLISH', stream_details_key, tinyjson)
+// Dropped returns the number of errors which could not be reported via
+// Errors() because the channel buffer was full.
+func (r *Run) Dropped() uint64 {
+	return r.dropped.Load()
+}
 
--- return ok status
-return 1
-`)
+// report sends err to the errors channel, or drops it if the buffer is full.
+func (r *Run) report(err error) {
+	// In this use case, dropping error on the floor is the desired behavior
+	// when the reader gets behind, because the updates are periodic.
+	select {
+	case r.errC <- err:
+	default:
+		r.dropped.Add(1)
+	}
+}
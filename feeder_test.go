@@ -0,0 +1,157 @@
+package fakefeeder_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	fakefeeder "github.com/emojitracker/emojitrack-fakefeeder"
+	"github.com/emojitracker/emojitrack-fakefeeder/fakefeedertest"
+)
+
+var testRankings = []fakefeeder.Ranking{
+	{Char: "😂", ID: "1F602", Name: "FACE WITH TEARS OF JOY", Score: 300},
+	{Char: "❤️", ID: "2764", Name: "HEAVY BLACK HEART", Score: 200},
+	{Char: "🔥", ID: "1F525", Name: "FIRE", Score: 100},
+}
+
+func seedScores(rs []fakefeeder.Ranking) map[string]int64 {
+	scores := make(map[string]int64, len(rs))
+	for _, r := range rs {
+		scores[r.ID] = r.Score
+	}
+	return scores
+}
+
+func TestNewFeederSeeds(t *testing.T) {
+	opts := fakefeeder.DefaultOptions()
+	opts.HistoryDepth = 4
+	_, sink := fakefeedertest.NewFeeder(t, testRankings, opts)
+
+	for _, r := range testRankings {
+		sink.AssertScore(t, r.ID, r.Score)
+		sink.AssertTweetCount(t, r.ID, 4)
+		tweets, err := sink.DecodedTweets(r.ID)
+		if err != nil {
+			t.Fatalf("seeded tweets for %s do not decode: %v", r.ID, err)
+		}
+		for _, tw := range tweets {
+			if tw.Text == "" || tw.CreatedAt.IsZero() {
+				t.Errorf("incomplete seeded tweet for %s: %+v", r.ID, tw)
+			}
+		}
+	}
+	sink.AssertPublished(t, "", 0)
+}
+
+func TestNewFeederSkipSeed(t *testing.T) {
+	opts := fakefeeder.DefaultOptions()
+	opts.SkipSeed = true
+	_, sink := fakefeedertest.NewFeeder(t, testRankings, opts)
+	if n := len(sink.Scores()); n != 0 {
+		t.Errorf("%d scores seeded with SkipSeed", n)
+	}
+}
+
+func TestUpdateEmoji(t *testing.T) {
+	f, sink := fakefeedertest.NewFeeder(t, testRankings, fakefeeder.DefaultOptions())
+	emoji := testRankings[2]
+	if err := f.UpdateEmoji(emoji); err != nil {
+		t.Fatal(err)
+	}
+
+	sink.AssertScore(t, emoji.ID, emoji.Score+1)
+	sink.AssertScore(t, testRankings[0].ID, testRankings[0].Score)
+	sink.AssertPublished(t, fakefeeder.ScoreUpdatesChannel, 1)
+	sink.AssertPublished(t, fakefeeder.TweetUpdatesPrefix+emoji.ID, 1)
+	sink.AssertTweetCount(t, emoji.ID, fakefeeder.DefaultHistoryDepth)
+
+	var tweet fakefeeder.EnsmallenedTweet
+	data := sink.Messages(fakefeeder.TweetUpdatesPrefix + emoji.ID)[0].Data
+	if err := json.Unmarshal(data, &tweet); err != nil {
+		t.Fatal(err)
+	}
+	if latest := sink.Tweets(emoji.ID)[0]; string(latest) != string(data) {
+		t.Error("published tweet is not the newest stored")
+	}
+	sink.AssertConsistent(t, seedScores(testRankings))
+}
+
+func TestReset(t *testing.T) {
+	f, sink := fakefeedertest.NewFeeder(t, testRankings, fakefeeder.DefaultOptions())
+	for i := 0; i < 20; i++ {
+		if err := f.Update(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sink.Increment("UNSEEDED")
+
+	if err := f.Reset(); err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range testRankings {
+		sink.AssertScore(t, r.ID, r.Score)
+		sink.AssertTweetCount(t, r.ID, fakefeeder.DefaultHistoryDepth)
+	}
+	if _, ok := sink.Score("UNSEEDED"); ok {
+		t.Error("Reset kept a score for an emoji not in the seed")
+	}
+}
+
+func TestStartWorkers(t *testing.T) {
+	f, sink := fakefeedertest.NewFeeder(t, testRankings, fakefeeder.DefaultOptions())
+	ctx, cancel := context.WithCancel(context.Background())
+	run := f.StartWorkers(ctx, time.Millisecond, 4)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for err := range run.Errors() {
+			t.Errorf("update failed: %v", err)
+		}
+	}()
+	sink.WaitForUpdates(t, 50, 5*time.Second)
+	cancel()
+	if err := run.Wait(); err != context.Canceled {
+		t.Errorf("Wait() = %v, want %v", err, context.Canceled)
+	}
+	wg.Wait()
+
+	sink.AssertConsistent(t, seedScores(testRankings))
+	for _, r := range testRankings {
+		sink.AssertTweetCount(t, r.ID, fakefeeder.DefaultHistoryDepth)
+	}
+}
+
+// Tweets backdated into a short window must still all have unique IDs.
+func TestSeedBackdatedTweetIDsUnique(t *testing.T) {
+	rs := make([]fakefeeder.Ranking, 500)
+	for i := range rs {
+		rs[i] = fakefeeder.Ranking{Char: "?", ID: fmt.Sprintf("1F%03X", i), Name: "TEST", Score: int64(i)}
+	}
+	opts := fakefeeder.DefaultOptions()
+	opts.HistoryDepth = 50
+	opts.HistoryWindow = time.Second
+	_, sink := fakefeedertest.NewFeeder(t, rs, opts)
+
+	seen := make(map[string]string)
+	for _, r := range rs {
+		tweets, err := sink.DecodedTweets(r.ID)
+		if err != nil {
+			t.Fatalf("seeded tweets for %s do not decode: %v", r.ID, err)
+		}
+		if len(tweets) != opts.HistoryDepth {
+			t.Fatalf("seeded %d tweets for %s, want %d", len(tweets), r.ID, opts.HistoryDepth)
+		}
+		for _, tw := range tweets {
+			if other, ok := seen[tw.ID]; ok {
+				t.Fatalf("tweet ID %s seeded for both %s and %s", tw.ID, other, r.ID)
+			}
+			seen[tw.ID] = r.ID
+		}
+	}
+}
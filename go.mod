@@ -1,9 +1,11 @@
 module github.com/emojitracker/emojitrack-fakefeeder
 
-go 1.16
+go 1.21
 
 require (
 	github.com/gomodule/redigo v1.8.4
 	github.com/icrowley/fake v0.0.0-20141223214152-84bff6d01560
 	github.com/mroth/weightedrand v0.4.1
 )
+
+require gopkg.in/yaml.v3 v3.0.1
@@ -0,0 +1,198 @@
+package fakefeeder
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Health describes the connectivity of a Feeder to its sink.
+type Health int32
+
+const (
+	// Connected indicates the most recent update succeeded.
+	Connected Health = iota
+	// Degraded indicates recent updates have failed, but not enough in a row
+	// to consider the sink down. The sink is checked, and re-seeded if
+	// necessary, before the next update.
+	Degraded
+	// Down indicates the sink is unreachable. Updates are not attempted while
+	// down, except for periodic reconnection attempts with exponential backoff.
+	Down
+)
+
+func (h Health) String() string {
+	switch h {
+	case Connected:
+		return "connected"
+	case Degraded:
+		return "degraded"
+	case Down:
+		return "down"
+	default:
+		return fmt.Sprintf("Health(%d)", int32(h))
+	}
+}
+
+// ErrSinkDown is returned by updates while the sink is down and awaiting
+// reconnection.
+var ErrSinkDown = errors.New("sink is down, awaiting reconnect")
+
+const (
+	downThreshold = 3 // consecutive transport failures before considered down
+	minBackoff    = 100 * time.Millisecond
+	maxBackoff    = 30 * time.Second
+)
+
+// Backoff is the capped exponential backoff used between attempts to
+// reconnect to a sink which is down, e.g. to retry NewFeeder until redis has
+// started. The zero value is ready to use.
+type Backoff struct {
+	d time.Duration
+}
+
+// Next returns the delay before the next attempt: 100ms at first, doubling on
+// each call up to 30s, with up to 20% jitter to avoid synchronized retries.
+func (b *Backoff) Next() time.Duration {
+	b.d = min(max(b.d*2, minBackoff), maxBackoff)
+	return b.d + time.Duration(rand.Int63n(int64(b.d)/5+1))
+}
+
+// Reset restarts the backoff from its shortest delay.
+func (b *Backoff) Reset() {
+	b.d = 0
+}
+
+// healthState tracks the Health of a Feeder and schedules reconnection
+// attempts while down.
+type healthState struct {
+	mu        sync.Mutex
+	state     Health
+	failures  int
+	backoff   Backoff
+	nextProbe time.Time
+	probing   bool
+}
+
+// Health returns the current health of the connection to the sink.
+func (f *Feeder) Health() Health {
+	f.health.mu.Lock()
+	defer f.health.mu.Unlock()
+	return f.health.state
+}
+
+// checkHealth returns nil if an update should be attempted. While the sink is
+// down, it returns ErrSinkDown, except for the single caller which is due to
+// attempt reconnecting.
+//
+// After any failure, the sink is checked (and re-seeded if it has come back
+// empty) before the next update, even if it never went down: a redis which
+// restarts quickly would otherwise have its keys recreated by that update, and
+// never be re-seeded. Other updates are skipped with ErrSinkDown meanwhile.
+func (f *Feeder) checkHealth() error {
+	h := &f.health
+	h.mu.Lock()
+	if h.state == Connected {
+		h.mu.Unlock()
+		return nil
+	}
+	if h.probing || (h.state == Down && time.Now().Before(h.nextProbe)) {
+		h.mu.Unlock()
+		return ErrSinkDown
+	}
+	h.probing = true
+	h.mu.Unlock()
+
+	err := f.reconnect()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.probing = false
+	switch {
+	case err != nil && h.state == Down:
+		h.scheduleProbe()
+		f.logHealth("reconnect failed", h.state, err)
+		return fmt.Errorf("%w: %v", ErrSinkDown, err)
+	case err != nil:
+		f.recordFailure(err)
+		return err
+	case h.state == Down:
+		f.logHealth("reconnected to sink", Connected, nil)
+	default:
+		f.logHealth("sink recovered", Connected, nil)
+	}
+	h.state, h.failures = Connected, 0
+	return nil
+}
+
+// reconnect checks the sink is reachable again, and re-seeds it if it has come
+// back empty (e.g. a restarted redis without persistence).
+func (f *Feeder) reconnect() error {
+	if err := f.sink.Prepare(f.opts.sinkConfig()); err != nil {
+		return err
+	}
+	seeded, err := f.sink.Seeded()
+	if err != nil {
+		return err
+	}
+	if !seeded {
+		f.logHealth("sink has lost seed data, re-seeding", f.Health(), nil)
+		return f.init()
+	}
+	return nil
+}
+
+// recordResult updates the health state following the outcome of an update.
+// Errors from the sink itself (e.g. a script error) are not connectivity
+// problems, so do not count towards the sink being down.
+func (f *Feeder) recordResult(err error) {
+	var scriptErr *ScriptError
+	h := &f.health
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	switch {
+	case err == nil:
+		h.failures = 0
+	case errors.Is(err, ErrSinkDown), errors.As(err, &scriptErr):
+		return
+	default:
+		f.recordFailure(err)
+	}
+}
+
+// recordFailure counts a failure to reach the sink, marking it degraded or,
+// after enough consecutive failures, down. f.health.mu must be held.
+func (f *Feeder) recordFailure(err error) {
+	h := &f.health
+	h.failures++
+	if h.failures >= downThreshold && h.state != Down {
+		h.state = Down
+		h.backoff.Reset()
+		h.scheduleProbe()
+		f.logHealth("sink is down, will attempt to reconnect", h.state, err)
+	} else if h.state == Connected {
+		h.state = Degraded
+		f.logHealth("sink is degraded", h.state, err)
+	}
+}
+
+// scheduleProbe sets the next reconnection attempt using the backoff. h.mu must
+// be held.
+func (h *healthState) scheduleProbe() {
+	h.nextProbe = time.Now().Add(h.backoff.Next())
+}
+
+// logHealth logs a change in health to state, with err if non-nil.
+func (f *Feeder) logHealth(msg string, state Health, err error) {
+	if f.Logger == nil {
+		return
+	}
+	if err != nil {
+		f.Logger.Warn(msg, "health", state, "error", err, "error_class", ErrorClass(err))
+		return
+	}
+	f.Logger.Info(msg, "health", state)
+}
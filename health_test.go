@@ -0,0 +1,89 @@
+package fakefeeder_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	fakefeeder "github.com/emojitracker/emojitrack-fakefeeder"
+	"github.com/emojitracker/emojitrack-fakefeeder/fakefeedertest"
+)
+
+var errUnreachable = &fakefeeder.TransportError{Err: errors.New("connection refused")}
+
+// A redis restarting empty within fewer failures than it takes to be
+// considered down must still be re-seeded before updates resume.
+func TestReseedAfterDegraded(t *testing.T) {
+	f, sink := fakefeedertest.NewFeeder(t, testRankings, fakefeeder.DefaultOptions())
+
+	ids := make([]string, len(testRankings))
+	for i, r := range testRankings {
+		ids[i] = r.ID
+	}
+	sink.Clear(ids)
+	sink.SetError(errUnreachable)
+	if err := f.UpdateEmoji(testRankings[0]); err == nil {
+		t.Fatal("update succeeded while unreachable")
+	}
+	if h := f.Health(); h != fakefeeder.Degraded {
+		t.Fatalf("health = %v, want %v", h, fakefeeder.Degraded)
+	}
+
+	sink.SetError(nil)
+	if err := f.UpdateEmoji(testRankings[0]); err != nil {
+		t.Fatal(err)
+	}
+	if h := f.Health(); h != fakefeeder.Connected {
+		t.Errorf("health = %v, want %v", h, fakefeeder.Connected)
+	}
+	sink.AssertScore(t, testRankings[0].ID, testRankings[0].Score+1)
+	for _, r := range testRankings[1:] {
+		sink.AssertScore(t, r.ID, r.Score)
+		sink.AssertTweetCount(t, r.ID, fakefeeder.DefaultHistoryDepth)
+	}
+}
+
+func TestDownAfterRepeatedFailures(t *testing.T) {
+	f, sink := fakefeedertest.NewFeeder(t, testRankings, fakefeeder.DefaultOptions())
+	sink.SetError(errUnreachable)
+	for i := 0; i < 5; i++ {
+		f.Update()
+	}
+	if h := f.Health(); h != fakefeeder.Down {
+		t.Fatalf("health = %v, want %v", h, fakefeeder.Down)
+	}
+	if err := f.Update(); !errors.Is(err, fakefeeder.ErrSinkDown) {
+		t.Errorf("Update() while down = %v, want %v", err, fakefeeder.ErrSinkDown)
+	}
+}
+
+// Script errors are not connectivity problems.
+func TestScriptErrorKeepsConnected(t *testing.T) {
+	f, sink := fakefeedertest.NewFeeder(t, testRankings, fakefeeder.DefaultOptions())
+	sink.SetError(&fakefeeder.ScriptError{Err: errors.New("ERR boom")})
+	for i := 0; i < 5; i++ {
+		f.Update()
+	}
+	if h := f.Health(); h != fakefeeder.Connected {
+		t.Errorf("health = %v, want %v", h, fakefeeder.Connected)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	var b fakefeeder.Backoff
+	want := 100 * time.Millisecond
+	for i := 0; i < 12; i++ {
+		got := b.Next()
+		if got < want || got > want+want/5 {
+			t.Errorf("attempt %d: Next() = %v, want %v plus up to 20%%", i, got, want)
+		}
+		if want = 2 * want; want > 30*time.Second {
+			want = 30 * time.Second
+		}
+	}
+
+	b.Reset()
+	if got := b.Next(); got > 120*time.Millisecond {
+		t.Errorf("Next() after Reset = %v, want about 100ms", got)
+	}
+}
@@ -0,0 +1,55 @@
+package fakefeeder
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+)
+
+// SampleHandler is a slog.Handler which only passes through one in every N
+// records at or below Level to the wrapped Handler, while records above Level
+// are always passed through.
+//
+// It is intended for sampling the per-update debug logging of a Feeder, which
+// would otherwise produce a log line for every single update.
+type SampleHandler struct {
+	handler slog.Handler
+	level   slog.Leveler
+	n       uint64
+	count   *atomic.Uint64
+}
+
+// NewSampleHandler returns a SampleHandler wrapping h, which passes through
+// one in every n records at or below level. An n of 0 or 1 disables sampling.
+func NewSampleHandler(h slog.Handler, level slog.Leveler, n uint64) *SampleHandler {
+	return &SampleHandler{handler: h, level: level, n: n, count: new(atomic.Uint64)}
+}
+
+// Enabled implements slog.Handler.
+func (h *SampleHandler) Enabled(ctx context.Context, l slog.Level) bool {
+	return h.handler.Enabled(ctx, l)
+}
+
+// Handle implements slog.Handler.
+func (h *SampleHandler) Handle(ctx context.Context, r slog.Record) error {
+	if h.n > 1 && r.Level <= h.level.Level() && h.count.Add(1)%h.n != 1 {
+		return nil
+	}
+	return h.handler.Handle(ctx, r)
+}
+
+// WithAttrs implements slog.Handler. The returned Handler shares its sampling
+// counter with h.
+func (h *SampleHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	h2 := *h
+	h2.handler = h.handler.WithAttrs(attrs)
+	return &h2
+}
+
+// WithGroup implements slog.Handler. The returned Handler shares its sampling
+// counter with h.
+func (h *SampleHandler) WithGroup(name string) slog.Handler {
+	h2 := *h
+	h2.handler = h.handler.WithGroup(name)
+	return &h2
+}
@@ -0,0 +1,80 @@
+package fakefeeder
+
+import "time"
+
+// Options configures a Feeder. The zero value is valid, but note it disables
+// weighting; DefaultOptions returns the defaults used by the fakefeeder
+// command.
+type Options struct {
+	// Weighted determines whether the updates generated will be
+	// probablistically weighted based on past scores rather than uniform
+	// random distribution.
+	Weighted bool
+	// Weighting, if non-nil, determines the relative probability of each emoji
+	// being chosen instead of Weighted, e.g. Zipf or Temperature.
+	Weighting Weighting
+	// Chooser, if non-nil, builds the Chooser used to pick the emoji for each
+	// update from the rankings instead, overriding Weighted, Weighting and
+	// Dynamics, e.g. NewRoundRobinChooser.
+	Chooser func(rs []Ranking) (Chooser, error)
+	// Dynamics determines how the probabilities of emoji being chosen change
+	// as updates are sent. The zero value, Static, keeps them fixed.
+	Dynamics Dynamics
+	// Gain is the weight added to an emoji for each update sent for it under
+	// RichGetRicher, which must be positive. Zero means 1, i.e. weights follow
+	// the running scores with Proportional weighting.
+	Gain float64
+	// FlattenHalfLife is the time taken for the probabilities to move halfway
+	// to uniform under Flatten. Zero means DefaultFlattenHalfLife.
+	FlattenHalfLife time.Duration
+
+	// HistoryDepth is the number of recent tweets kept for each emoji, both
+	// when seeding and updating. Zero means DefaultHistoryDepth.
+	HistoryDepth int
+	// HistoryWindow spreads the CreatedAt times of the tweets seeded for each
+	// emoji randomly over this period before seeding. Zero means they are all
+	// created at the time of seeding.
+	HistoryWindow time.Duration
+
+	// SeedChunkSize is the maximum number of emoji seeded to the sink in a
+	// single batch (for a RedisSink, a single MULTI/EXEC transaction). Zero
+	// means DefaultSeedChunkSize.
+	SeedChunkSize int
+	// SeedParallelism is the number of batches seeded concurrently. Zero
+	// means batches are seeded one at a time.
+	SeedParallelism int
+	// SeedProgress, if non-nil, is called after each batch has been seeded.
+	// Calls are never concurrent, even when seeding in parallel.
+	SeedProgress func(SeedProgress)
+	// SkipSeed leaves any data already in the sink untouched when creating a
+	// Feeder, instead of seeding it, e.g. to carry on from data seeded by an
+	// earlier Feeder. Reset, and reconnecting to a sink which has lost its
+	// data, still seed as usual.
+	SkipSeed bool
+}
+
+// Defaults for Options fields when zero.
+const (
+	DefaultHistoryDepth  = 10
+	DefaultSeedChunkSize = 250
+)
+
+// DefaultOptions returns the Options used by the fakefeeder command unless
+// overridden.
+func DefaultOptions() Options {
+	return Options{
+		Weighted:        true,
+		HistoryDepth:    DefaultHistoryDepth,
+		SeedChunkSize:   DefaultSeedChunkSize,
+		SeedParallelism: 1,
+	}
+}
+
+// sinkConfig returns the SinkConfig corresponding to o.
+func (o Options) sinkConfig() SinkConfig {
+	depth := o.HistoryDepth
+	if depth <= 0 {
+		depth = DefaultHistoryDepth
+	}
+	return SinkConfig{HistoryDepth: depth}
+}
@@ -0,0 +1,86 @@
+package fakefeeder
+
+import (
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// PoolOptions configures the redis.Pool created by NewPool, and the
+// connections within it. Zero values have the same meaning as in redis.Pool,
+// e.g. a MaxActive of zero allows an unlimited number of connections, and a
+// zero timeout never times out.
+type PoolOptions struct {
+	MaxIdle         int           `yaml:"max_idle"`          // maximum idle connections
+	MaxActive       int           `yaml:"max_active"`        // maximum connections allocated at once
+	IdleTimeout     time.Duration `yaml:"idle_timeout"`      // close connections idle for longer
+	MaxConnLifetime time.Duration `yaml:"max_conn_lifetime"` // close connections older than this
+	Wait            bool          `yaml:"wait"`              // wait for a connection when MaxActive is reached, rather than erroring
+
+	ConnectTimeout time.Duration `yaml:"connect_timeout"`
+	ReadTimeout    time.Duration `yaml:"read_timeout"`
+	WriteTimeout   time.Duration `yaml:"write_timeout"`
+}
+
+// DefaultPoolOptions returns the PoolOptions used by the fakefeeder command
+// unless overridden.
+func DefaultPoolOptions() PoolOptions {
+	return PoolOptions{
+		MaxIdle:        3,
+		IdleTimeout:    240 * time.Second,
+		ConnectTimeout: 5 * time.Second,
+		ReadTimeout:    5 * time.Second,
+		WriteTimeout:   5 * time.Second,
+	}
+}
+
+// NewPool returns a redis.Pool for the redis instance at targetURL (e.g.
+// "redis://:password@localhost:6379"), configured with opts. Connections from
+// the pool identify themselves to redis as ClientName.
+//
+// The target URL is parsed to extract host and password (redigo doesn't
+// natively understand URI format).
+func NewPool(targetURL string, opts PoolOptions) (*redis.Pool, error) {
+	url, err := url.Parse(targetURL)
+	if err != nil {
+		return nil, errors.New("could not parse target URL")
+	}
+	server := url.Host
+	password := ""
+	if url.User != nil {
+		password, _ = url.User.Password()
+	}
+
+	dialOpts := []redis.DialOption{
+		redis.DialClientName(ClientName),
+		redis.DialConnectTimeout(opts.ConnectTimeout),
+		redis.DialReadTimeout(opts.ReadTimeout),
+		redis.DialWriteTimeout(opts.WriteTimeout),
+	}
+	if password != "" {
+		dialOpts = append(dialOpts, redis.DialPassword(password))
+	}
+
+	return &redis.Pool{
+		MaxIdle:         opts.MaxIdle,
+		MaxActive:       opts.MaxActive,
+		IdleTimeout:     opts.IdleTimeout,
+		MaxConnLifetime: opts.MaxConnLifetime,
+		Wait:            opts.Wait,
+		Dial: func() (redis.Conn, error) {
+			return redis.Dial("tcp", server, dialOpts...)
+		},
+		// check connections which have sat idle for a while are still alive
+		// (e.g. redis may have restarted) before handing them out, rather than
+		// failing the update that borrows them
+		TestOnBorrow: func(c redis.Conn, t time.Time) error {
+			if time.Since(t) < time.Second {
+				return nil
+			}
+			_, err := c.Do("PING")
+			return err
+		},
+	}, nil
+}
@@ -0,0 +1,75 @@
+package rankings
+
+import (
+	"regexp"
+	"sort"
+
+	fakefeeder "github.com/emojitracker/emojitrack-fakefeeder"
+)
+
+// Filter selects a subset of rankings, e.g. to feed only a handful of emoji.
+// The zero value selects all rankings.
+//
+// Filtering by Categories or Versions requires Meta to be set on the rankings,
+// see WithMetadata.
+type Filter struct {
+	Include    []string       // if non-empty, only these emoji (by ID or char)
+	Exclude    []string       // never these emoji (by ID or char)
+	Name       *regexp.Regexp // if non-nil, only emoji with a matching Name
+	Categories []string       // if non-empty, only emoji in these categories or subcategories
+	Versions   []string       // if non-empty, only emoji introduced in these emoji versions
+	Top        int            // if positive, only the Top highest scoring of those remaining
+}
+
+// Apply returns the rankings in rs selected by f. Rankings are returned in
+// their original order, and rs is not modified.
+func (f Filter) Apply(rs []fakefeeder.Ranking) []fakefeeder.Ranking {
+	include := set(f.Include)
+	exclude := set(f.Exclude)
+	categories := set(f.Categories)
+	versions := set(f.Versions)
+
+	results := make([]fakefeeder.Ranking, 0, len(rs))
+	for _, r := range rs {
+		switch {
+		case len(include) > 0 && !include[r.ID] && !include[r.Char]:
+		case exclude[r.ID] || exclude[r.Char]:
+		case f.Name != nil && !f.Name.MatchString(r.Name):
+		case len(categories) > 0 && (r.Meta == nil || !categories[r.Meta.Category] && !categories[r.Meta.Subcategory]):
+		case len(versions) > 0 && (r.Meta == nil || !versions[r.Meta.EmojiVersion]):
+		default:
+			results = append(results, r)
+		}
+	}
+
+	if f.Top > 0 && f.Top < len(results) {
+		byScore := make([]int, len(results))
+		for i := range byScore {
+			byScore[i] = i
+		}
+		sort.SliceStable(byScore, func(i, j int) bool {
+			return results[byScore[i]].Score > results[byScore[j]].Score
+		})
+		top := make(map[int]bool, f.Top)
+		for _, i := range byScore[:f.Top] {
+			top[i] = true
+		}
+		n := 0
+		for i, r := range results {
+			if top[i] {
+				results[n] = r
+				n++
+			}
+		}
+		results = results[:n]
+	}
+	return results
+}
+
+func set(ss []string) map[string]bool {
+	m := make(map[string]bool, len(ss))
+	for _, s := range ss {
+		m[s] = true
+	}
+	return m
+}
@@ -0,0 +1,165 @@
+package rankings
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"strings"
+	"sync"
+
+	fakefeeder "github.com/emojitracker/emojitrack-fakefeeder"
+)
+
+// emojiTestData is the Unicode emoji test data file, from
+// https://unicode.org/Public/emoji/latest/emoji-test.txt. To update the
+// bundled metadata, replace it with a newer version.
+//
+//go:embed data/emoji-test.txt
+var emojiTestData string
+
+var (
+	parseOnce   sync.Once
+	emojiList   []fakefeeder.Ranking           // fully-qualified emoji, in CLDR order
+	emojiByID   map[string]fakefeeder.Metadata // metadata by emojitracker ID
+	skinToneCPs = map[string]bool{"1F3FB": true, "1F3FC": true, "1F3FD": true, "1F3FE": true, "1F3FF": true}
+)
+
+// Metadata returns the bundled Unicode metadata for the emoji with the given
+// emojitracker style ID (e.g. "1F602" or "1F469-200D-1F4BB").
+func Metadata(id string) (fakefeeder.Metadata, bool) {
+	parseOnce.Do(parseEmojiTestData)
+	m, ok := emojiByID[id]
+	return m, ok
+}
+
+// WithMetadata returns a copy of rs with Meta set from the bundled Unicode
+// metadata, for those rankings which it covers.
+func WithMetadata(rs []fakefeeder.Ranking) []fakefeeder.Ranking {
+	results := make([]fakefeeder.Ranking, len(rs))
+	for i, r := range rs {
+		if m, ok := Metadata(r.ID); ok {
+			r.Meta = &m
+		}
+		results[i] = r
+	}
+	return results
+}
+
+// Unicode returns a zero scored Ranking (with Meta) for every fully-qualified
+// emoji in the bundled Unicode data, in CLDR order. Names are upper-cased to
+// match the style of the Emojitracker API.
+func Unicode() []fakefeeder.Ranking {
+	parseOnce.Do(parseEmojiTestData)
+	results := make([]fakefeeder.Ranking, len(emojiList))
+	copy(results, emojiList)
+	return results
+}
+
+// EmojiID converts a sequence of code points to an emojitracker style ID: the
+// upper-case hex code points, padded to at least 4 digits and joined by "-",
+// omitting any variation selector-16 (FE0F).
+func EmojiID(cps []rune) string {
+	parts := make([]string, 0, len(cps))
+	for _, cp := range cps {
+		if cp == 0xFE0F {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%04X", cp))
+	}
+	return strings.Join(parts, "-")
+}
+
+// parseEmojiTestData populates emojiList and emojiByID from emojiTestData.
+//
+// Lines are of the format:
+//
+//	1F44B 1F3FB ; fully-qualified # 👋🏻 E1.0 waving hand: light skin tone
+func parseEmojiTestData() {
+	emojiByID = make(map[string]fakefeeder.Metadata)
+	var group, subgroup string
+	var withTones []string
+
+	sc := bufio.NewScanner(strings.NewReader(emojiTestData))
+	for sc.Scan() {
+		line := sc.Text()
+		switch {
+		case strings.HasPrefix(line, "# group: "):
+			group = strings.TrimPrefix(line, "# group: ")
+			continue
+		case strings.HasPrefix(line, "# subgroup: "):
+			subgroup = strings.TrimPrefix(line, "# subgroup: ")
+			continue
+		case line == "" || strings.HasPrefix(line, "#"):
+			continue
+		}
+
+		fields, comment, ok := strings.Cut(line, "#")
+		if !ok {
+			continue
+		}
+		cpField, status, _ := strings.Cut(fields, ";")
+		if strings.TrimSpace(status) != "fully-qualified" {
+			continue
+		}
+		// comment is "<char> E<version> <name>"
+		parts := strings.SplitN(strings.TrimSpace(comment), " ", 3)
+		if len(parts) != 3 || !strings.HasPrefix(parts[1], "E") {
+			continue
+		}
+
+		hex := strings.Fields(cpField)
+		base := make([]string, 0, len(hex))
+		tones, zwj := false, false
+		for _, h := range hex {
+			switch {
+			case skinToneCPs[h]:
+				tones = true
+				continue // not part of the base emoji
+			case h == "200D":
+				zwj = true
+			}
+			base = append(base, h)
+		}
+		id := EmojiID(parseHex(hex))
+		if tones {
+			// skin tone variants mark their base emoji as supporting them
+			withTones = append(withTones, EmojiID(parseHex(base)))
+		}
+
+		m := fakefeeder.Metadata{
+			Category:     group,
+			Subcategory:  subgroup,
+			EmojiVersion: strings.TrimPrefix(parts[1], "E"),
+			ZWJ:          zwj,
+		}
+		emojiByID[id] = m
+		emojiList = append(emojiList, fakefeeder.Ranking{
+			Char: parts[0],
+			ID:   id,
+			Name: strings.ToUpper(parts[2]),
+		})
+	}
+
+	for _, id := range withTones {
+		if m, ok := emojiByID[id]; ok {
+			m.SkinTones = true
+			emojiByID[id] = m
+		}
+	}
+	for i, r := range emojiList {
+		m := emojiByID[r.ID]
+		emojiList[i].Meta = &m
+	}
+}
+
+// parseHex parses hex code points, ignoring any which are invalid.
+func parseHex(hex []string) []rune {
+	cps := make([]rune, 0, len(hex))
+	for _, h := range hex {
+		var cp rune
+		if _, err := fmt.Sscanf(h, "%X", &cp); err == nil {
+			cps = append(cps, cp)
+		}
+	}
+	return cps
+}
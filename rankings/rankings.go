@@ -1,10 +1,15 @@
 package rankings
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
-	"io/ioutil"
+	"fmt"
+	"io"
+	"net"
 	"net/http"
+	"net/url"
+	"time"
 
 	fakefeeder "github.com/emojitracker/emojitrack-fakefeeder"
 )
@@ -14,30 +19,174 @@ const (
 	EmojitrackerV1APIRankingsURL = "https://api.emojitracker.com/v1/rankings"
 )
 
+// defaults for Client
+const (
+	DefaultTimeout   = 30 * time.Second
+	DefaultRetries   = 2
+	DefaultRetryWait = time.Second
+	DefaultMaxSize   = 10 << 20 // bytes, the full v1 rankings are ~100KB
+)
+
 // Live retrieves the live rankings from the provided Emojitracker API
-// endpoint url.
+// endpoint url, using a Client with the default settings.
 //
 // This is primarily used when generating a new snapshot, but is provided
 // in the public methods in case fresher data is needed for some reason.
-func Live(url string) (results []fakefeeder.Ranking, err error) {
-	resp, err := http.Get(url)
+func Live(url string) ([]fakefeeder.Ranking, error) {
+	var c Client
+	return c.Rankings(context.Background(), url)
+}
+
+// Client retrieves rankings from an Emojitracker API endpoint. The zero value
+// is ready to use with the defaults noted for each field.
+type Client struct {
+	HTTPClient *http.Client  // if nil, http.DefaultClient
+	Timeout    time.Duration // limit for each attempt, including reading the body; if zero, DefaultTimeout
+	Retries    int           // additional attempts after a transient failure; if zero, DefaultRetries, if negative, none
+	RetryWait  time.Duration // wait before the first retry, doubling after each; if zero, DefaultRetryWait
+	MaxSize    int64         // limit on the response body in bytes; if zero, DefaultMaxSize
+}
+
+// StatusError is returned by Client.Rankings when the endpoint responds with a
+// status other than 200 OK.
+type StatusError struct {
+	URL  string
+	Code int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("not OK status when retrieving remote rankings from %s: %d %s",
+		e.URL, e.Code, http.StatusText(e.Code))
+}
+
+// ErrInvalid is wrapped by the errors returned for rankings which are too
+// large, malformed, or fail Validate.
+var ErrInvalid = errors.New("invalid rankings")
+
+// Rankings retrieves and validates the rankings from the Emojitracker API
+// endpoint url.
+//
+// Network errors, timeouts, and 5xx and 429 responses are retried; any other
+// error, or cancellation of ctx, is returned immediately.
+func (c *Client) Rankings(ctx context.Context, url string) ([]fakefeeder.Ranking, error) {
+	retries := c.Retries
+	switch {
+	case retries == 0:
+		retries = DefaultRetries
+	case retries < 0:
+		retries = 0
+	}
+	wait := c.RetryWait
+	if wait == 0 {
+		wait = DefaultRetryWait
+	}
+
+	for attempt := 0; ; attempt++ {
+		results, err := c.fetch(ctx, url)
+		if err == nil || attempt == retries || !retryable(err) || ctx.Err() != nil {
+			return results, err
+		}
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return nil, ctx.Err()
+		case <-t.C:
+		}
+		wait *= 2
+	}
+}
+
+// fetch makes a single attempt at retrieving the rankings from url.
+func (c *Client) fetch(ctx context.Context, url string) ([]fakefeeder.Ranking, error) {
+	timeout := c.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	maxSize := c.MaxSize
+	if maxSize == 0 {
+		maxSize = DefaultMaxSize
+	}
+	hc := c.HTTPClient
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return
+		return nil, err
 	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		err = errors.New("not OK status when retrieving remote rankings")
-		return
+		io.Copy(io.Discard, io.LimitReader(resp.Body, 4096)) // allow connection reuse
+		return nil, &StatusError{URL: url, Code: resp.StatusCode}
 	}
 
-	defer resp.Body.Close()
-	dat, err := ioutil.ReadAll(resp.Body)
+	dat, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
 	if err != nil {
-		return
+		return nil, err
+	}
+	if int64(len(dat)) > maxSize {
+		return nil, fmt.Errorf("%w: response exceeds %d bytes", ErrInvalid, maxSize)
 	}
 
-	err = json.Unmarshal(dat, &results)
-	return
+	var results []fakefeeder.Ranking
+	if err := json.Unmarshal(dat, &results); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	if err := Validate(results); err != nil {
+		return nil, err
+	}
+	return results, nil
 }
 
-//go:generate go run ./scripts/generate_snapshot.go -- snapshot.go
+// retryable reports whether an error from fetch may succeed on another attempt:
+// a network error or timeout, or a 5xx or 429 status. Anything else, such as
+// an invalid URL or response, would fail the same way again.
+func retryable(err error) bool {
+	var se *StatusError
+	if errors.As(err, &se) {
+		return se.Code >= 500 || se.Code == http.StatusTooManyRequests
+	}
+	// the http.Client wraps every error in a *url.Error, which is a net.Error
+	// whatever the cause, so look at the cause itself
+	var ue *url.Error
+	if errors.As(err, &ue) {
+		err = ue.Err
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// Validate checks that rs is usable as seed data: it must be non-empty, and
+// every ranking must have a unique, non-empty ID and a non-negative score. The
+// error returned wraps ErrInvalid.
+func Validate(rs []fakefeeder.Ranking) error {
+	if len(rs) == 0 {
+		return fmt.Errorf("%w: no rankings", ErrInvalid)
+	}
+	seen := make(map[string]bool, len(rs))
+	for i, r := range rs {
+		switch {
+		case r.ID == "":
+			return fmt.Errorf("%w: ranking %d has no ID", ErrInvalid, i)
+		case seen[r.ID]:
+			return fmt.Errorf("%w: duplicate ID %s", ErrInvalid, r.ID)
+		case r.Score < 0:
+			return fmt.Errorf("%w: negative score %d for %s", ErrInvalid, r.Score, r.ID)
+		}
+		seen[r.ID] = true
+	}
+	return nil
+}
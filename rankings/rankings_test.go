@@ -0,0 +1,101 @@
+package rankings
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const testRankings = `[{"char":"😂","id":"1F602","name":"FACE WITH TEARS OF JOY","score":300}]`
+
+// countingServer returns a server responding with code and body, and a
+// counter of the requests it has received.
+func countingServer(t *testing.T, code int, body string) (*httptest.Server, *atomic.Int32) {
+	var n atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n.Add(1)
+		w.WriteHeader(code)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &n
+}
+
+func TestRankingsRetries(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		code     int
+		body     string
+		attempts int32
+		ok       bool
+	}{
+		{"ok", http.StatusOK, testRankings, 1, true},
+		{"server error", http.StatusServiceUnavailable, "", 3, false},
+		{"too many requests", http.StatusTooManyRequests, "", 3, false},
+		{"not found", http.StatusNotFound, "", 1, false},
+		{"malformed", http.StatusOK, "not json", 1, false},
+		{"invalid", http.StatusOK, "[]", 1, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			srv, n := countingServer(t, tc.code, tc.body)
+			c := Client{Retries: 2, RetryWait: time.Millisecond}
+			_, err := c.Rankings(context.Background(), srv.URL)
+			if (err == nil) != tc.ok {
+				t.Errorf("Rankings() error = %v, want ok %t", err, tc.ok)
+			}
+			if got := n.Load(); got != tc.attempts {
+				t.Errorf("made %d attempts, want %d", got, tc.attempts)
+			}
+		})
+	}
+}
+
+func TestRankingsRetriesNetworkError(t *testing.T) {
+	srv, _ := countingServer(t, http.StatusOK, testRankings)
+	srv.Close() // connection refused
+
+	var attempts atomic.Int32
+	hc := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		attempts.Add(1)
+		return http.DefaultTransport.RoundTrip(r)
+	})}
+	c := Client{HTTPClient: hc, Retries: 2, RetryWait: time.Millisecond}
+	if _, err := c.Rankings(context.Background(), srv.URL); err == nil {
+		t.Fatal("Rankings() succeeded against a closed server")
+	}
+	if got := attempts.Load(); got != 3 {
+		t.Errorf("made %d attempts, want 3", got)
+	}
+}
+
+// Errors which would recur on every attempt are returned immediately, rather
+// than after waiting out the retries.
+func TestRankingsBadURLNotRetried(t *testing.T) {
+	for _, url := range []string{
+		"://missing-scheme",
+		"ftp://example.com/rankings",
+		"http://[::1",
+	} {
+		c := Client{Retries: 2, RetryWait: time.Hour}
+		done := make(chan error, 1)
+		go func() {
+			_, err := c.Rankings(context.Background(), url)
+			done <- err
+		}()
+		select {
+		case err := <-done:
+			if err == nil {
+				t.Errorf("Rankings(%q) succeeded", url)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Rankings(%q) retried", url)
+		}
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
@@ -3,83 +3,343 @@
 package main
 
 import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"log"
+	"math"
 	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
-	"text/template"
 	"time"
 
+	"github.com/gomodule/redigo/redis"
+
 	fakefeeder "github.com/emojitracker/emojitrack-fakefeeder"
 	rankings "github.com/emojitracker/emojitrack-fakefeeder/rankings"
 )
 
-type rankingCollection []fakefeeder.Ranking
-
-// Override output format for code generation, creates identical output for the
-// data structure to the old hand-written Go from the previous Ruby script.
-func (r rankingCollection) GoString() string {
-	var b strings.Builder
-	b.WriteString("[]fakefeeder.Ranking{")
-	for _, v := range r {
-		b.WriteString(
-			fmt.Sprintf(
-				"\n\t{Char: \"%s\", ID: \"%s\", Name: \"%s\", Score: %d},",
-				v.Char, v.ID, v.Name, v.Score,
-			),
-		)
-	}
-	b.WriteString("\n}")
-	return b.String()
-}
+var (
+	from   = flag.String("from", rankings.EmojitrackerV1APIRankingsURL, "source of rankings: an API URL, a redis:// URL, a file path, or - for stdin")
+	format = flag.String("format", "json", "format of a file or stdin source: json (as the rankings API, or an uncompressed snapshot) or zset (redis-cli ZREVRANGE WITHSCORES output)")
+	key    = flag.String("key", "emojitrack_score", "score zset key for a redis source")
+	top    = flag.Int("top", 10, "number of biggest changes to show in the diff summary")
+	dryRun = flag.Bool("n", false, "print the diff summary only, without writing the output file")
+)
 
-const snapshotTmpl = `// Code generated via scripts/generate_snapshot.go -- DO NOT EDIT.
-// Data obtained from {{.Source}} at {{ .Time.Format "2006-01-02 15:04:05 -0700" }}.
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage:", os.Args[0], "[flags] <output-file>")
+		fmt.Fprintln(os.Stderr, "\nWrites a snapshot to output-file, e.g. data/snapshots/2020.json.gz.")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 && !*dryRun {
+		flag.Usage()
+		os.Exit(1)
+	}
 
-package rankings
+	ranks, source, err := read(*from, *format)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := rankings.Validate(ranks); err != nil {
+		log.Fatalf("%s: %v", source, err)
+	}
+	sort.SliceStable(ranks, func(i, j int) bool {
+		return ranks[i].Score > ranks[j].Score
+	})
+	for i := range ranks {
+		ranks[i].Meta = nil // metadata is joined on at runtime
+	}
 
-import "github.com/emojitracker/emojitrack-fakefeeder"
+	// compare against the snapshot being replaced, if any, or the default
+	name := strings.TrimSuffix(filepath.Base(flag.Arg(0)), ".json.gz")
+	base, err := rankings.NamedSnapshot(name)
+	if err != nil {
+		name = rankings.DefaultSnapshot
+		base = rankings.Snapshot()
+	}
+	fmt.Fprintf(os.Stderr, "compared to snapshot %s: ", name)
+	printDiff(os.Stderr, base, ranks, *top)
+	if *dryRun {
+		return
+	}
 
-// Snapshot returns the archived snapshot of rankings from the Emojitracker API.
-func Snapshot() []fakefeeder.Ranking {
-	return snapshotData
+	err = write(flag.Arg(0), rankings.SnapshotFile{
+		Source:    source,
+		CreatedAt: time.Now().Truncate(time.Second),
+		Rankings:  ranks,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
 }
 
-var snapshotData = {{ printf "%#v" .Data }}
-`
+// write stores sf to path as gzipped JSON.
+func write(path string, sf rankings.SnapshotFile) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
 
-var tmpl = template.Must(template.New("snapshot").Parse(snapshotTmpl))
+	zw, err := gzip.NewWriterLevel(f, gzip.BestCompression)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(zw)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(sf); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	return f.Close()
+}
 
-func main() {
-	flag.Parse()
-	if flag.NArg() != 1 {
-		fmt.Println("args", flag.Args())
-		fmt.Fprintln(os.Stderr, "usage:", os.Args[0], "<output-file>")
-		os.Exit(1)
+// read loads rankings from src, returning them along with a description of the
+// source for the generated file header.
+func read(src, format string) ([]fakefeeder.Ranking, string, error) {
+	switch {
+	case strings.HasPrefix(src, "http://"), strings.HasPrefix(src, "https://"):
+		ranks, err := rankings.Live(src)
+		return ranks, src, err
+	case strings.HasPrefix(src, "redis://"), strings.HasPrefix(src, "rediss://"):
+		ranks, err := readRedis(src, *key)
+		return ranks, "redis " + *key + " zset", err
+	case src == "-":
+		ranks, err := decode(os.Stdin, format)
+		return ranks, "stdin", err
 	}
 
-	ranks, err := rankings.Live(rankings.EmojitrackerV1APIRankingsURL)
+	f, err := os.Open(src)
 	if err != nil {
-		log.Fatal(err)
+		return nil, "", err
 	}
+	defer f.Close()
+	ranks, err := decode(f, format)
+	return ranks, src, err
+}
 
-	f, err := os.Create(flag.Arg(0))
-	if err != nil {
-		log.Fatal(err)
+// decode reads rankings from r in the given format.
+func decode(r io.Reader, format string) ([]fakefeeder.Ranking, error) {
+	switch format {
+	case "json":
+		// either an API response, or an uncompressed snapshot file
+		var raw json.RawMessage
+		if err := json.NewDecoder(r).Decode(&raw); err != nil {
+			return nil, fmt.Errorf("could not parse rankings JSON: %w", err)
+		}
+		if strings.HasPrefix(strings.TrimSpace(string(raw)), "{") {
+			var sf rankings.SnapshotFile
+			if err := json.Unmarshal(raw, &sf); err != nil {
+				return nil, fmt.Errorf("could not parse snapshot JSON: %w", err)
+			}
+			return sf.Rankings, nil
+		}
+		var ranks []fakefeeder.Ranking
+		if err := json.Unmarshal(raw, &ranks); err != nil {
+			return nil, fmt.Errorf("could not parse rankings JSON: %w", err)
+		}
+		return ranks, nil
+	case "zset":
+		return decodeZset(r)
 	}
+	return nil, fmt.Errorf("unknown format %q", format)
+}
 
-	data := struct {
-		Source string
-		Time   time.Time
-		Data   rankingCollection
-	}{
-		rankings.EmojitrackerV1APIRankingsURL,
-		time.Now(),
-		ranks,
+// decodeZset parses a dump of a score zset with alternating member and score
+// lines, as output by redis-cli ZREVRANGE <key> 0 -1 WITHSCORES. Line numbers
+// and quotes added by redis-cli in interactive mode are stripped.
+func decodeZset(r io.Reader) ([]fakefeeder.Ranking, error) {
+	var pairs []string
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		if i := strings.Index(line, ") "); i > 0 {
+			if _, err := strconv.Atoi(line[:i]); err == nil {
+				line = line[i+2:]
+			}
+		}
+		pairs = append(pairs, strings.Trim(line, `"`))
 	}
-	err = tmpl.Execute(f, data)
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if len(pairs)%2 != 0 {
+		return nil, fmt.Errorf("zset dump has an odd number of lines (%d)", len(pairs))
+	}
+	return zsetRankings(pairs)
+}
+
+// readRedis loads rankings from the score zset key in the redis at url.
+func readRedis(url, key string) ([]fakefeeder.Ranking, error) {
+	c, err := redis.DialURL(url)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
+	}
+	defer c.Close()
+	pairs, err := redis.Strings(c.Do("ZREVRANGE", key, 0, -1, "WITHSCORES"))
+	if err != nil {
+		return nil, err
+	}
+	return zsetRankings(pairs)
+}
+
+// zsetRankings converts alternating member and score pairs to rankings. Since a
+// zset only stores IDs, chars and names are filled in from the bundled snapshots
+// or Unicode data.
+func zsetRankings(pairs []string) ([]fakefeeder.Ranking, error) {
+	known := make(map[string]fakefeeder.Ranking)
+	for _, r := range rankings.Unicode() {
+		known[r.ID] = r
+	}
+	for _, name := range rankings.Snapshots() {
+		rs, err := rankings.NamedSnapshot(name)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range rs {
+			known[r.ID] = r
+		}
+	}
+
+	ranks := make([]fakefeeder.Ranking, 0, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		id := pairs[i]
+		score, err := strconv.ParseFloat(pairs[i+1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid score for %s: %w", id, err)
+		}
+		r, ok := known[id]
+		if !ok {
+			return nil, fmt.Errorf("unknown emoji ID %q", id)
+		}
+		ranks = append(ranks, fakefeeder.Ranking{
+			Char:  r.Char,
+			ID:    id,
+			Name:  r.Name,
+			Score: int64(math.Round(score)),
+		})
+	}
+	return ranks, nil
+}
+
+// printDiff writes a summary of the differences between the old and new
+// rankings to w: added and removed emoji, and the top biggest score changes
+// and rank shifts.
+func printDiff(w io.Writer, before, after []fakefeeder.Ranking, top int) {
+	type entry struct {
+		r    fakefeeder.Ranking
+		rank int
+	}
+	index := func(rs []fakefeeder.Ranking) map[string]entry {
+		m := make(map[string]entry, len(rs))
+		for i, r := range rs {
+			m[r.ID] = entry{r, i + 1}
+		}
+		return m
+	}
+	oldIdx, newIdx := index(before), index(after)
+
+	type change struct {
+		r                   fakefeeder.Ranking
+		oldScore, scoreDiff int64
+		oldRank, rankDiff   int
+	}
+	var added, removed []fakefeeder.Ranking
+	var changes []change
+	for _, r := range after {
+		o, ok := oldIdx[r.ID]
+		if !ok {
+			added = append(added, r)
+			continue
+		}
+		n := newIdx[r.ID]
+		changes = append(changes, change{
+			r:         r,
+			oldScore:  o.r.Score,
+			oldRank:   o.rank,
+			scoreDiff: r.Score - o.r.Score,
+			rankDiff:  o.rank - n.rank,
+		})
+	}
+	for _, r := range before {
+		if _, ok := newIdx[r.ID]; !ok {
+			removed = append(removed, r)
+		}
+	}
+
+	fmt.Fprintf(w, "%d emoji (was %d): %d added, %d removed, %d in both\n",
+		len(after), len(before), len(added), len(removed), len(changes))
+	list := func(title string, rs []fakefeeder.Ranking) {
+		if len(rs) == 0 {
+			return
+		}
+		fmt.Fprintf(w, "\n%s:\n", title)
+		for i, r := range rs {
+			if i == top {
+				fmt.Fprintf(w, "  ... and %d more\n", len(rs)-top)
+				break
+			}
+			fmt.Fprintf(w, "  %s %-20s %s\n", r.Char, r.ID, r.Name)
+		}
+	}
+	list("added", added)
+	list("removed", removed)
+
+	abs := func(n int64) int64 {
+		if n < 0 {
+			return -n
+		}
+		return n
+	}
+	section := func(title string, less func(a, b change) bool, show func(c change) string) {
+		sort.SliceStable(changes, func(i, j int) bool { return less(changes[i], changes[j]) })
+		var lines []string
+		for _, c := range changes {
+			if len(lines) == top {
+				break
+			}
+			if s := show(c); s != "" {
+				lines = append(lines, s)
+			}
+		}
+		if len(lines) == 0 {
+			return
+		}
+		fmt.Fprintf(w, "\n%s:\n", title)
+		for _, l := range lines {
+			fmt.Fprintln(w, l)
+		}
 	}
+	section("biggest score changes",
+		func(a, b change) bool { return abs(a.scoreDiff) > abs(b.scoreDiff) },
+		func(c change) string {
+			if c.scoreDiff == 0 {
+				return ""
+			}
+			return fmt.Sprintf("  %s %-20s %+d (%d -> %d)", c.r.Char, c.r.ID, c.scoreDiff, c.oldScore, c.r.Score)
+		},
+	)
+	section("biggest rank shifts",
+		func(a, b change) bool { return abs(int64(a.rankDiff)) > abs(int64(b.rankDiff)) },
+		func(c change) string {
+			if c.rankDiff == 0 {
+				return ""
+			}
+			return fmt.Sprintf("  %s %-20s %+d (#%d -> #%d)", c.r.Char, c.r.ID, c.rankDiff, c.oldRank, c.oldRank-c.rankDiff)
+		},
+	)
 }
@@ -1,859 +1,105 @@
-// Code generated via scripts/generate_snapshot.go -- DO NOT EDIT.
-// Data obtained from https://api.emojitracker.com/v1/rankings at 2020-06-16 16:50:47 -0400.
-
 package rankings
 
-import "github.com/emojitracker/emojitrack-fakefeeder"
+import (
+	"compress/gzip"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	fakefeeder "github.com/emojitracker/emojitrack-fakefeeder"
+)
+
+// snapshotFiles are the bundled snapshots of rankings, as gzipped SnapshotFile
+// JSON named <name>.json.gz. To add a snapshot, generate one with
+// scripts/generate_snapshot.go.
+//
+//go:embed data/snapshots/*.json.gz
+var snapshotFiles embed.FS
+
+const snapshotDir = "data/snapshots"
+
+// DefaultSnapshot is the name of the snapshot returned by Snapshot.
+const DefaultSnapshot = "2020"
+
+// SnapshotFile is the format of a bundled snapshot, before compression.
+type SnapshotFile struct {
+	Source    string               `json:"source"`     // where the rankings were obtained from
+	CreatedAt time.Time            `json:"created_at"` // when the rankings were obtained
+	Rankings  []fakefeeder.Ranking `json:"rankings"`
+}
+
+var (
+	snapshotMu    sync.Mutex
+	snapshotCache = make(map[string][]fakefeeder.Ranking)
+)
 
-// Snapshot returns the archived snapshot of rankings from the Emojitracker API.
+// Snapshot returns the default archived snapshot of rankings from the
+// Emojitracker API.
 func Snapshot() []fakefeeder.Ranking {
-	return snapshotData
+	rs, err := NamedSnapshot(DefaultSnapshot)
+	if err != nil {
+		panic(err) // the default snapshot is always bundled
+	}
+	return rs
 }
 
-var snapshotData = []fakefeeder.Ranking{
-	{Char: "😂", ID: "1F602", Name: "FACE WITH TEARS OF JOY", Score: 2876567482},
-	{Char: "❤️", ID: "2764", Name: "HEAVY BLACK HEART", Score: 1399715825},
-	{Char: "😍", ID: "1F60D", Name: "SMILING FACE WITH HEART-SHAPED EYES", Score: 1027014911},
-	{Char: "♻️", ID: "267B", Name: "BLACK UNIVERSAL RECYCLING SYMBOL", Score: 966452932},
-	{Char: "😭", ID: "1F62D", Name: "LOUDLY CRYING FACE", Score: 929585635},
-	{Char: "♥️", ID: "2665", Name: "BLACK HEART SUIT", Score: 769355383},
-	{Char: "😊", ID: "1F60A", Name: "SMILING FACE WITH SMILING EYES", Score: 664197866},
-	{Char: "💕", ID: "1F495", Name: "TWO HEARTS", Score: 514743101},
-	{Char: "😒", ID: "1F612", Name: "UNAMUSED FACE", Score: 511857325},
-	{Char: "😘", ID: "1F618", Name: "FACE THROWING A KISS", Score: 495991974},
-	{Char: "😩", ID: "1F629", Name: "WEARY FACE", Score: 447433172},
-	{Char: "😔", ID: "1F614", Name: "PENSIVE FACE", Score: 425635978},
-	{Char: "☺️", ID: "263A", Name: "WHITE SMILING FACE", Score: 403935162},
-	{Char: "😁", ID: "1F601", Name: "GRINNING FACE WITH SMILING EYES", Score: 391483304},
-	{Char: "👌", ID: "1F44C", Name: "OK HAND SIGN", Score: 374194659},
-	{Char: "😏", ID: "1F60F", Name: "SMIRKING FACE", Score: 348885813},
-	{Char: "😉", ID: "1F609", Name: "WINKING FACE", Score: 331047241},
-	{Char: "👍", ID: "1F44D", Name: "THUMBS UP SIGN", Score: 299644643},
-	{Char: "😅", ID: "1F605", Name: "SMILING FACE WITH OPEN MOUTH AND COLD SWEAT", Score: 286498467},
-	{Char: "🙏", ID: "1F64F", Name: "PERSON WITH FOLDED HANDS", Score: 275036606},
-	{Char: "👀", ID: "1F440", Name: "EYES", Score: 271419750},
-	{Char: "💔", ID: "1F494", Name: "BROKEN HEART", Score: 256742642},
-	{Char: "😌", ID: "1F60C", Name: "RELIEVED FACE", Score: 255397831},
-	{Char: "😢", ID: "1F622", Name: "CRYING FACE", Score: 253217239},
-	{Char: "🔥", ID: "1F525", Name: "FIRE", Score: 251203745},
-	{Char: "💙", ID: "1F499", Name: "BLUE HEART", Score: 248981786},
-	{Char: "😎", ID: "1F60E", Name: "SMILING FACE WITH SUNGLASSES", Score: 248558735},
-	{Char: "💜", ID: "1F49C", Name: "PURPLE HEART", Score: 234466155},
-	{Char: "⬅️", ID: "2B05", Name: "LEFTWARDS BLACK ARROW", Score: 232126806},
-	{Char: "🎶", ID: "1F3B6", Name: "MULTIPLE MUSICAL NOTES", Score: 225563684},
-	{Char: "😳", ID: "1F633", Name: "FLUSHED FACE", Score: 218946926},
-	{Char: "✨", ID: "2728", Name: "SPARKLES", Score: 216991282},
-	{Char: "💖", ID: "1F496", Name: "SPARKLING HEART", Score: 201596906},
-	{Char: "🙌", ID: "1F64C", Name: "PERSON RAISING BOTH HANDS IN CELEBRATION", Score: 199703746},
-	{Char: "💯", ID: "1F4AF", Name: "HUNDRED POINTS SYMBOL", Score: 196488867},
-	{Char: "🙈", ID: "1F648", Name: "SEE-NO-EVIL MONKEY", Score: 189818442},
-	{Char: "✌️", ID: "270C", Name: "VICTORY HAND", Score: 175582213},
-	{Char: "😄", ID: "1F604", Name: "SMILING FACE WITH OPEN MOUTH AND SMILING EYES", Score: 172471823},
-	{Char: "😑", ID: "1F611", Name: "EXPRESSIONLESS FACE", Score: 169309510},
-	{Char: "😴", ID: "1F634", Name: "SLEEPING FACE", Score: 169124424},
-	{Char: "😋", ID: "1F60B", Name: "FACE SAVOURING DELICIOUS FOOD", Score: 167960575},
-	{Char: "😜", ID: "1F61C", Name: "FACE WITH STUCK-OUT TONGUE AND WINKING EYE", Score: 159256337},
-	{Char: "😞", ID: "1F61E", Name: "DISAPPOINTED FACE", Score: 153685941},
-	{Char: "😪", ID: "1F62A", Name: "SLEEPY FACE", Score: 153255790},
-	{Char: "😕", ID: "1F615", Name: "CONFUSED FACE", Score: 151043648},
-	{Char: "💗", ID: "1F497", Name: "GROWING HEART", Score: 148635383},
-	{Char: "👏", ID: "1F44F", Name: "CLAPPING HANDS SIGN", Score: 146341418},
-	{Char: "😐", ID: "1F610", Name: "NEUTRAL FACE", Score: 142861168},
-	{Char: "👉", ID: "1F449", Name: "WHITE RIGHT POINTING BACKHAND INDEX", Score: 142662579},
-	{Char: "💞", ID: "1F49E", Name: "REVOLVING HEARTS", Score: 132258537},
-	{Char: "💛", ID: "1F49B", Name: "YELLOW HEART", Score: 130890124},
-	{Char: "🌹", ID: "1F339", Name: "ROSE", Score: 126997402},
-	{Char: "💪", ID: "1F4AA", Name: "FLEXED BICEPS", Score: 126657363},
-	{Char: "😱", ID: "1F631", Name: "FACE SCREAMING IN FEAR", Score: 124937999},
-	{Char: "💘", ID: "1F498", Name: "HEART WITH ARROW", Score: 123509720},
-	{Char: "💀", ID: "1F480", Name: "SKULL", Score: 122953508},
-	{Char: "😆", ID: "1F606", Name: "SMILING FACE WITH OPEN MOUTH AND TIGHTLY-CLOSED EYES", Score: 119888324},
-	{Char: "📷", ID: "1F4F7", Name: "CAMERA", Score: 118876840},
-	{Char: "😡", ID: "1F621", Name: "POUTING FACE", Score: 118490503},
-	{Char: "🌸", ID: "1F338", Name: "CHERRY BLOSSOM", Score: 114921896},
-	{Char: "😀", ID: "1F600", Name: "GRINNING FACE", Score: 113129351},
-	{Char: "💋", ID: "1F48B", Name: "KISS MARK", Score: 113005723},
-	{Char: "😈", ID: "1F608", Name: "SMILING FACE WITH HORNS", Score: 107553657},
-	{Char: "💁", ID: "1F481", Name: "INFORMATION DESK PERSON", Score: 105741401},
-	{Char: "👈", ID: "1F448", Name: "WHITE LEFT POINTING BACKHAND INDEX", Score: 104669059},
-	{Char: "🎉", ID: "1F389", Name: "PARTY POPPER", Score: 104562143},
-	{Char: "🙊", ID: "1F64A", Name: "SPEAK-NO-EVIL MONKEY", Score: 104316363},
-	{Char: "😃", ID: "1F603", Name: "SMILING FACE WITH OPEN MOUTH", Score: 103229208},
-	{Char: "💚", ID: "1F49A", Name: "GREEN HEART", Score: 101831127},
-	{Char: "😫", ID: "1F62B", Name: "TIRED FACE", Score: 101432808},
-	{Char: "😤", ID: "1F624", Name: "FACE WITH LOOK OF TRIUMPH", Score: 98994552},
-	{Char: "▶️", ID: "25B6", Name: "BLACK RIGHT-POINTING TRIANGLE", Score: 97216371},
-	{Char: "✋", ID: "270B", Name: "RAISED HAND", Score: 95208579},
-	{Char: "😝", ID: "1F61D", Name: "FACE WITH STUCK-OUT TONGUE AND TIGHTLY-CLOSED EYES", Score: 93461027},
-	{Char: "💓", ID: "1F493", Name: "BEATING HEART", Score: 93401946},
-	{Char: "🌚", ID: "1F31A", Name: "NEW MOON WITH FACE", Score: 92006658},
-	{Char: "✅", ID: "2705", Name: "WHITE HEAVY CHECK MARK", Score: 91557360},
-	{Char: "😣", ID: "1F623", Name: "PERSEVERING FACE", Score: 86136517},
-	{Char: "😬", ID: "1F62C", Name: "GRIMACING FACE", Score: 85596715},
-	{Char: "✔️", ID: "2714", Name: "HEAVY CHECK MARK", Score: 83834712},
-	{Char: "➡️", ID: "27A1", Name: "BLACK RIGHTWARDS ARROW", Score: 83610121},
-	{Char: "☀️", ID: "2600", Name: "BLACK SUN WITH RAYS", Score: 83387968},
-	{Char: "👊", ID: "1F44A", Name: "FISTED HAND SIGN", Score: 83378186},
-	{Char: "😇", ID: "1F607", Name: "SMILING FACE WITH HALO", Score: 82585024},
-	{Char: "😓", ID: "1F613", Name: "FACE WITH COLD SWEAT", Score: 81980455},
-	{Char: "👑", ID: "1F451", Name: "CROWN", Score: 80605391},
-	{Char: "👇", ID: "1F447", Name: "WHITE DOWN POINTING BACKHAND INDEX", Score: 80533562},
-	{Char: "😻", ID: "1F63B", Name: "SMILING CAT FACE WITH HEART-SHAPED EYES", Score: 79513029},
-	{Char: "🔴", ID: "1F534", Name: "LARGE RED CIRCLE", Score: 77434726},
-	{Char: "😥", ID: "1F625", Name: "DISAPPOINTED BUT RELIEVED FACE", Score: 76439463},
-	{Char: "😛", ID: "1F61B", Name: "FACE WITH STUCK-OUT TONGUE", Score: 69720497},
-	{Char: "😚", ID: "1F61A", Name: "KISSING FACE WITH CLOSED EYES", Score: 67407587},
-	{Char: "😷", ID: "1F637", Name: "FACE WITH MEDICAL MASK", Score: 66209402},
-	{Char: "👋", ID: "1F44B", Name: "WAVING HAND SIGN", Score: 66008273},
-	{Char: "💥", ID: "1F4A5", Name: "COLLISION SYMBOL", Score: 64329472},
-	{Char: "⚡️", ID: "26A1", Name: "HIGH VOLTAGE SIGN", Score: 63684168},
-	{Char: "⭐️", ID: "2B50", Name: "WHITE MEDIUM STAR", Score: 57966849},
-	{Char: "😠", ID: "1F620", Name: "ANGRY FACE", Score: 56531778},
-	{Char: "😶", ID: "1F636", Name: "FACE WITHOUT MOUTH", Score: 56153363},
-	{Char: "😖", ID: "1F616", Name: "CONFOUNDED FACE", Score: 55824211},
-	{Char: "🎧", ID: "1F3A7", Name: "HEADPHONE", Score: 54784898},
-	{Char: "🌟", ID: "1F31F", Name: "GLOWING STAR", Score: 53828053},
-	{Char: "💰", ID: "1F4B0", Name: "MONEY BAG", Score: 53696975},
-	{Char: "🎵", ID: "1F3B5", Name: "MUSICAL NOTE", Score: 50485837},
-	{Char: "💫", ID: "1F4AB", Name: "DIZZY SYMBOL", Score: 49097630},
-	{Char: "‼️", ID: "203C", Name: "DOUBLE EXCLAMATION MARK", Score: 48771823},
-	{Char: "💃", ID: "1F483", Name: "DANCER", Score: 47111713},
-	{Char: "🙋", ID: "1F64B", Name: "HAPPY PERSON RAISING ONE HAND", Score: 46338802},
-	{Char: "🌺", ID: "1F33A", Name: "HIBISCUS", Score: 44320402},
-	{Char: "👎", ID: "1F44E", Name: "THUMBS DOWN SIGN", Score: 43587831},
-	{Char: "⚽️", ID: "26BD", Name: "SOCCER BALL", Score: 42723995},
-	{Char: "1️⃣", ID: "0031-20E3", Name: "KEYCAP 1", Score: 42592854},
-	{Char: "✊", ID: "270A", Name: "RAISED FIST", Score: 41553606},
-	{Char: "💦", ID: "1F4A6", Name: "SPLASHING SWEAT SYMBOL", Score: 41425052},
-	{Char: "😹", ID: "1F639", Name: "CAT FACE WITH TEARS OF JOY", Score: 41178120},
-	{Char: "💩", ID: "1F4A9", Name: "PILE OF POO", Score: 41102081},
-	{Char: "😰", ID: "1F630", Name: "FACE WITH OPEN MOUTH AND COLD SWEAT", Score: 41030944},
-	{Char: "✈️", ID: "2708", Name: "AIRPLANE", Score: 40990600},
-	{Char: "2️⃣", ID: "0032-20E3", Name: "KEYCAP 2", Score: 40894381},
-	{Char: "👅", ID: "1F445", Name: "TONGUE", Score: 40766671},
-	{Char: "🌞", ID: "1F31E", Name: "SUN WITH FACE", Score: 39462279},
-	{Char: "🔫", ID: "1F52B", Name: "PISTOL", Score: 38287515},
-	{Char: "©", ID: "00A9", Name: "COPYRIGHT SIGN", Score: 37718673},
-	{Char: "💭", ID: "1F4AD", Name: "THOUGHT BALLOON", Score: 37217943},
-	{Char: "🍃", ID: "1F343", Name: "LEAF FLUTTERING IN WIND", Score: 37188332},
-	{Char: "💎", ID: "1F48E", Name: "GEM STONE", Score: 36240032},
-	{Char: "↪️", ID: "21AA", Name: "RIGHTWARDS ARROW WITH HOOK", Score: 35505346},
-	{Char: "🎈", ID: "1F388", Name: "BALLOON", Score: 34134814},
-	{Char: "🍀", ID: "1F340", Name: "FOUR LEAF CLOVER", Score: 34065646},
-	{Char: "❌", ID: "274C", Name: "CROSS MARK", Score: 33778715},
-	{Char: "👻", ID: "1F47B", Name: "GHOST", Score: 32950364},
-	{Char: "❗️", ID: "2757", Name: "HEAVY EXCLAMATION MARK SYMBOL", Score: 32245815},
-	{Char: "◀️", ID: "25C0", Name: "BLACK LEFT-POINTING TRIANGLE", Score: 32231554},
-	{Char: "😟", ID: "1F61F", Name: "WORRIED FACE", Score: 31971229},
-	{Char: "↩️", ID: "21A9", Name: "LEFTWARDS ARROW WITH HOOK", Score: 31909357},
-	{Char: "💤", ID: "1F4A4", Name: "SLEEPING SYMBOL", Score: 31374657},
-	{Char: "🙆", ID: "1F646", Name: "FACE WITH OK GESTURE", Score: 31322672},
-	{Char: "☎️", ID: "260E", Name: "BLACK TELEPHONE", Score: 30831634},
-	{Char: "🙅", ID: "1F645", Name: "FACE WITH NO GOOD GESTURE", Score: 30604518},
-	{Char: "🌷", ID: "1F337", Name: "TULIP", Score: 30468597},
-	{Char: "🎤", ID: "1F3A4", Name: "MICROPHONE", Score: 30426162},
-	{Char: "💐", ID: "1F490", Name: "BOUQUET", Score: 30253730},
-	{Char: "😨", ID: "1F628", Name: "FEARFUL FACE", Score: 30175396},
-	{Char: "🌈", ID: "1F308", Name: "RAINBOW", Score: 29883674},
-	{Char: "🎥", ID: "1F3A5", Name: "MOVIE CAMERA", Score: 29613261},
-	{Char: "😮", ID: "1F62E", Name: "FACE WITH OPEN MOUTH", Score: 29434765},
-	{Char: "🔞", ID: "1F51E", Name: "NO ONE UNDER EIGHTEEN SYMBOL", Score: 29358972},
-	{Char: "☕️", ID: "2615", Name: "HOT BEVERAGE", Score: 29245737},
-	{Char: "🎊", ID: "1F38A", Name: "CONFETTI BALL", Score: 29108706},
-	{Char: "😙", ID: "1F619", Name: "KISSING FACE WITH SMILING EYES", Score: 28852134},
-	{Char: "🍕", ID: "1F355", Name: "SLICE OF PIZZA", Score: 28750991},
-	{Char: "🇺🇸", ID: "1F1FA-1F1F8", Name: "REGIONAL INDICATOR SYMBOL LETTERS US", Score: 28687519},
-	{Char: "🎀", ID: "1F380", Name: "RIBBON", Score: 28631048},
-	{Char: "🎁", ID: "1F381", Name: "WRAPPED PRESENT", Score: 28630673},
-	{Char: "🌙", ID: "1F319", Name: "CRESCENT MOON", Score: 28503703},
-	{Char: "☝️", ID: "261D", Name: "WHITE UP POINTING INDEX", Score: 28435597},
-	{Char: "🍻", ID: "1F37B", Name: "CLINKING BEER MUGS", Score: 28226814},
-	{Char: "❄️", ID: "2744", Name: "SNOWFLAKE", Score: 27675367},
-	{Char: "☑️", ID: "2611", Name: "BALLOT BOX WITH CHECK", Score: 27608949},
-	{Char: "🌻", ID: "1F33B", Name: "SUNFLOWER", Score: 27159427},
-	{Char: "🏆", ID: "1F3C6", Name: "TROPHY", Score: 27084302},
-	{Char: "🎄", ID: "1F384", Name: "CHRISTMAS TREE", Score: 27023851},
-	{Char: "🎂", ID: "1F382", Name: "BIRTHDAY CAKE", Score: 26970131},
-	{Char: "☁️", ID: "2601", Name: "CLOUD", Score: 25628046},
-	{Char: "🌴", ID: "1F334", Name: "PALM TREE", Score: 25113912},
-	{Char: "🚶", ID: "1F6B6", Name: "PEDESTRIAN", Score: 25032244},
-	{Char: "😲", ID: "1F632", Name: "ASTONISHED FACE", Score: 24800352},
-	{Char: "💝", ID: "1F49D", Name: "HEART WITH RIBBON", Score: 24640700},
-	{Char: "🐶", ID: "1F436", Name: "DOG FACE", Score: 24522241},
-	{Char: "👐", ID: "1F450", Name: "OPEN HANDS SIGN", Score: 24331450},
-	{Char: "😧", ID: "1F627", Name: "ANGUISHED FACE", Score: 23567420},
-	{Char: "🌿", ID: "1F33F", Name: "HERB", Score: 23372807},
-	{Char: "💅", ID: "1F485", Name: "NAIL POLISH", Score: 23318297},
-	{Char: "👽", ID: "1F47D", Name: "EXTRATERRESTRIAL ALIEN", Score: 23243865},
-	{Char: "💟", ID: "1F49F", Name: "HEART DECORATION", Score: 22991384},
-	{Char: "🐳", ID: "1F433", Name: "SPOUTING WHALE", Score: 22907469},
-	{Char: "🐧", ID: "1F427", Name: "PENGUIN", Score: 22848336},
-	{Char: "🙇", ID: "1F647", Name: "PERSON BOWING DEEPLY", Score: 22832440},
-	{Char: "😯", ID: "1F62F", Name: "HUSHED FACE", Score: 22192854},
-	{Char: "😵", ID: "1F635", Name: "DIZZY FACE", Score: 21238507},
-	{Char: "🚀", ID: "1F680", Name: "ROCKET", Score: 21209372},
-	{Char: "🍂", ID: "1F342", Name: "FALLEN LEAF", Score: 21143177},
-	{Char: "🌎", ID: "1F30E", Name: "EARTH GLOBE AMERICAS", Score: 21096588},
-	{Char: "⚠️", ID: "26A0", Name: "WARNING SIGN", Score: 21089319},
-	{Char: "💸", ID: "1F4B8", Name: "MONEY WITH WINGS", Score: 21084789},
-	{Char: "🌼", ID: "1F33C", Name: "BLOSSOM", Score: 20497386},
-	{Char: "👼", ID: "1F47C", Name: "BABY ANGEL", Score: 20168883},
-	{Char: "♣️", ID: "2663", Name: "BLACK CLUB SUIT", Score: 19995378},
-	{Char: "🌍", ID: "1F30D", Name: "EARTH GLOBE EUROPE-AFRICA", Score: 19867858},
-	{Char: "🏃", ID: "1F3C3", Name: "RUNNER", Score: 19843920},
-	{Char: "🏀", ID: "1F3C0", Name: "BASKETBALL AND HOOP", Score: 19561792},
-	{Char: "🌝", ID: "1F31D", Name: "FULL MOON WITH FACE", Score: 19452157},
-	{Char: "🍁", ID: "1F341", Name: "MAPLE LEAF", Score: 19356390},
-	{Char: "♨️", ID: "2668", Name: "HOT SPRINGS", Score: 19279487},
-	{Char: "👯", ID: "1F46F", Name: "WOMAN WITH BUNNY EARS", Score: 19001969},
-	{Char: "🐱", ID: "1F431", Name: "CAT FACE", Score: 18975002},
-	{Char: "📹", ID: "1F4F9", Name: "VIDEO CAMERA", Score: 18870472},
-	{Char: "🚨", ID: "1F6A8", Name: "POLICE CARS REVOLVING LIGHT", Score: 18312400},
-	{Char: "👆", ID: "1F446", Name: "WHITE UP POINTING BACKHAND INDEX", Score: 18155237},
-	{Char: "💌", ID: "1F48C", Name: "LOVE LETTER", Score: 17932778},
-	{Char: "💨", ID: "1F4A8", Name: "DASH SYMBOL", Score: 17292767},
-	{Char: "😿", ID: "1F63F", Name: "CRYING CAT FACE", Score: 17270926},
-	{Char: "⬇️", ID: "2B07", Name: "DOWNWARDS BLACK ARROW", Score: 17251990},
-	{Char: "🌊", ID: "1F30A", Name: "WATER WAVE", Score: 17064284},
-	{Char: "🎼", ID: "1F3BC", Name: "MUSICAL SCORE", Score: 16947473},
-	{Char: "📲", ID: "1F4F2", Name: "MOBILE PHONE WITH RIGHTWARDS ARROW AT LEFT", Score: 16946030},
-	{Char: "📢", ID: "1F4E2", Name: "PUBLIC ADDRESS LOUDSPEAKER", Score: 16755971},
-	{Char: "💍", ID: "1F48D", Name: "RING", Score: 16677286},
-	{Char: "✖️", ID: "2716", Name: "HEAVY MULTIPLICATION X", Score: 16655017},
-	{Char: "📞", ID: "1F4DE", Name: "TELEPHONE RECEIVER", Score: 16570105},
-	{Char: "🌵", ID: "1F335", Name: "CACTUS", Score: 16294576},
-	{Char: "📌", ID: "1F4CC", Name: "PUSHPIN", Score: 15920239},
-	{Char: "🍑", ID: "1F351", Name: "PEACH", Score: 15741189},
-	{Char: "👫", ID: "1F46B", Name: "MAN AND WOMAN HOLDING HANDS", Score: 15732528},
-	{Char: "📺", ID: "1F4FA", Name: "TELEVISION", Score: 15688341},
-	{Char: "⚓️", ID: "2693", Name: "ANCHOR", Score: 15646341},
-	{Char: "💣", ID: "1F4A3", Name: "BOMB", Score: 15509134},
-	{Char: "📱", ID: "1F4F1", Name: "MOBILE PHONE", Score: 15190775},
-	{Char: "📍", ID: "1F4CD", Name: "ROUND PUSHPIN", Score: 15073862},
-	{Char: "😗", ID: "1F617", Name: "KISSING FACE", Score: 14964382},
-	{Char: "🔊", ID: "1F50A", Name: "SPEAKER WITH THREE SOUND WAVES", Score: 14904869},
-	{Char: "👄", ID: "1F444", Name: "MOUTH", Score: 14559358},
-	{Char: "🎬", ID: "1F3AC", Name: "CLAPPER BOARD", Score: 14452450},
-	{Char: "🎯", ID: "1F3AF", Name: "DIRECT HIT", Score: 14401965},
-	{Char: "⏩", ID: "23E9", Name: "BLACK RIGHT-POINTING DOUBLE TRIANGLE", Score: 14376942},
-	{Char: "🐷", ID: "1F437", Name: "PIG FACE", Score: 14251521},
-	{Char: "♠️", ID: "2660", Name: "BLACK SPADE SUIT", Score: 14206152},
-	{Char: "🐐", ID: "1F410", Name: "GOAT", Score: 14191887},
-	{Char: "🔝", ID: "1F51D", Name: "TOP WITH UPWARDS ARROW ABOVE", Score: 14129114},
-	{Char: "🔵", ID: "1F535", Name: "LARGE BLUE CIRCLE", Score: 14113187},
-	{Char: "🐰", ID: "1F430", Name: "RABBIT FACE", Score: 14101921},
-	{Char: "💏", ID: "1F48F", Name: "KISS", Score: 14060404},
-	{Char: "😦", ID: "1F626", Name: "FROWNING FACE WITH OPEN MOUTH", Score: 13987730},
-	{Char: "🙉", ID: "1F649", Name: "HEAR-NO-EVIL MONKEY", Score: 13557270},
-	{Char: "👸", ID: "1F478", Name: "PRINCESS", Score: 13463315},
-	{Char: "👭", ID: "1F46D", Name: "TWO WOMEN HOLDING HANDS", Score: 13204915},
-	{Char: "☔️", ID: "2614", Name: "UMBRELLA WITH RAIN DROPS", Score: 13198903},
-	{Char: "👿", ID: "1F47F", Name: "IMP", Score: 13123904},
-	{Char: "📣", ID: "1F4E3", Name: "CHEERING MEGAPHONE", Score: 12980195},
-	{Char: "🔪", ID: "1F52A", Name: "HOCHO", Score: 12880460},
-	{Char: "🐝", ID: "1F41D", Name: "HONEYBEE", Score: 12801768},
-	{Char: "⏰", ID: "23F0", Name: "ALARM CLOCK", Score: 12758108},
-	{Char: "🍓", ID: "1F353", Name: "STRAWBERRY", Score: 12702938},
-	{Char: "🍒", ID: "1F352", Name: "CHERRIES", Score: 12678037},
-	{Char: "💆", ID: "1F486", Name: "FACE MASSAGE", Score: 12377321},
-	{Char: "🏈", ID: "1F3C8", Name: "AMERICAN FOOTBALL", Score: 12090776},
-	{Char: "🍺", ID: "1F37A", Name: "BEER MUG", Score: 12043743},
-	{Char: "🍆", ID: "1F346", Name: "AUBERGINE", Score: 12043398},
-	{Char: "😽", ID: "1F63D", Name: "KISSING CAT FACE WITH CLOSED EYES", Score: 11895525},
-	{Char: "🍭", ID: "1F36D", Name: "LOLLIPOP", Score: 11876712},
-	{Char: "🍷", ID: "1F377", Name: "WINE GLASS", Score: 11864536},
-	{Char: "🔸", ID: "1F538", Name: "SMALL ORANGE DIAMOND", Score: 11828618},
-	{Char: "⭕️", ID: "2B55", Name: "HEAVY LARGE CIRCLE", Score: 11818736},
-	{Char: "💢", ID: "1F4A2", Name: "ANGER SYMBOL", Score: 11574801},
-	{Char: "🎅", ID: "1F385", Name: "FATHER CHRISTMAS", Score: 11573836},
-	{Char: "🌏", ID: "1F30F", Name: "EARTH GLOBE ASIA-AUSTRALIA", Score: 11565030},
-	{Char: "😸", ID: "1F638", Name: "GRINNING CAT FACE WITH SMILING EYES", Score: 11426473},
-	{Char: "🌀", ID: "1F300", Name: "CYCLONE", Score: 11421117},
-	{Char: "🌱", ID: "1F331", Name: "SEEDLING", Score: 11358813},
-	{Char: "🐍", ID: "1F40D", Name: "SNAKE", Score: 11338241},
-	{Char: "💑", ID: "1F491", Name: "COUPLE WITH HEART", Score: 11308648},
-	{Char: "🐾", ID: "1F43E", Name: "PAW PRINTS", Score: 11067905},
-	{Char: "♦️", ID: "2666", Name: "BLACK DIAMOND SUIT", Score: 10987038},
-	{Char: "😼", ID: "1F63C", Name: "CAT FACE WITH WRY SMILE", Score: 10969684},
-	{Char: "🍩", ID: "1F369", Name: "DOUGHNUT", Score: 10938208},
-	{Char: "🎃", ID: "1F383", Name: "JACK-O-LANTERN", Score: 10861701},
-	{Char: "📝", ID: "1F4DD", Name: "MEMO", Score: 10785834},
-	{Char: "🎸", ID: "1F3B8", Name: "GUITAR", Score: 10757714},
-	{Char: "🐸", ID: "1F438", Name: "FROG FACE", Score: 10621598},
-	{Char: "🙀", ID: "1F640", Name: "WEARY CAT FACE", Score: 10564634},
-	{Char: "🐼", ID: "1F43C", Name: "PANDA FACE", Score: 10553194},
-	{Char: "💬", ID: "1F4AC", Name: "SPEECH BALLOON", Score: 10472599},
-	{Char: "🐻", ID: "1F43B", Name: "BEAR FACE", Score: 10461991},
-	{Char: "👩", ID: "1F469", Name: "WOMAN", Score: 10379270},
-	{Char: "📚", ID: "1F4DA", Name: "BOOKS", Score: 10372888},
-	{Char: "🇫🇷", ID: "1F1EB-1F1F7", Name: "REGIONAL INDICATOR SYMBOL LETTERS FR", Score: 10143258},
-	{Char: "🍌", ID: "1F34C", Name: "BANANA", Score: 10142642},
-	{Char: "🍟", ID: "1F35F", Name: "FRENCH FRIES", Score: 10139357},
-	{Char: "🎮", ID: "1F3AE", Name: "VIDEO GAME", Score: 10126982},
-	{Char: "🙍", ID: "1F64D", Name: "PERSON FROWNING", Score: 10110583},
-	{Char: "👶", ID: "1F476", Name: "BABY", Score: 10080801},
-	{Char: "🍉", ID: "1F349", Name: "WATERMELON", Score: 10068286},
-	{Char: "🔹", ID: "1F539", Name: "SMALL BLUE DIAMOND", Score: 10049418},
-	{Char: "😺", ID: "1F63A", Name: "SMILING CAT FACE WITH OPEN MOUTH", Score: 10004715},
-	{Char: "💡", ID: "1F4A1", Name: "ELECTRIC LIGHT BULB", Score: 9913247},
-	{Char: "🚮", ID: "1F6AE", Name: "PUT LITTER IN ITS PLACE SYMBOL", Score: 9836252},
-	{Char: "🚗", ID: "1F697", Name: "AUTOMOBILE", Score: 9682315},
-	{Char: "▪️", ID: "25AA", Name: "BLACK SMALL SQUARE", Score: 9540794},
-	{Char: "🚬", ID: "1F6AC", Name: "SMOKING SYMBOL", Score: 9399523},
-	{Char: "🆗", ID: "1F197", Name: "SQUARED OK", Score: 9331236},
-	{Char: "✏️", ID: "270F", Name: "PENCIL", Score: 9302099},
-	{Char: "🔮", ID: "1F52E", Name: "CRYSTAL BALL", Score: 9268403},
-	{Char: "🐣", ID: "1F423", Name: "HATCHING CHICK", Score: 9246554},
-	{Char: "🐯", ID: "1F42F", Name: "TIGER FACE", Score: 9158291},
-	{Char: "🌾", ID: "1F33E", Name: "EAR OF RICE", Score: 9085951},
-	{Char: "™", ID: "2122", Name: "TRADE MARK SIGN", Score: 9077832},
-	{Char: "👨", ID: "1F468", Name: "MAN", Score: 9019278},
-	{Char: "⛄️", ID: "26C4", Name: "SNOWMAN WITHOUT SNOW", Score: 8953295},
-	{Char: "👣", ID: "1F463", Name: "FOOTPRINTS", Score: 8866953},
-	{Char: "🚖", ID: "1F696", Name: "ONCOMING TAXI", Score: 8847693},
-	{Char: "💻", ID: "1F4BB", Name: "PERSONAL COMPUTER", Score: 8844752},
-	{Char: "🚫", ID: "1F6AB", Name: "NO ENTRY SIGN", Score: 8836371},
-	{Char: "🏡", ID: "1F3E1", Name: "HOUSE WITH GARDEN", Score: 8742965},
-	{Char: "⛅️", ID: "26C5", Name: "SUN BEHIND CLOUD", Score: 8735900},
-	{Char: "🍫", ID: "1F36B", Name: "CHOCOLATE BAR", Score: 8666810},
-	{Char: "📻", ID: "1F4FB", Name: "RADIO", Score: 8632567},
-	{Char: "🍹", ID: "1F379", Name: "TROPICAL DRINK", Score: 8611830},
-	{Char: "🍴", ID: "1F374", Name: "FORK AND KNIFE", Score: 8568055},
-	{Char: "🐥", ID: "1F425", Name: "FRONT-FACING BABY CHICK", Score: 8543623},
-	{Char: "🇬🇧", ID: "1F1EC-1F1E7", Name: "REGIONAL INDICATOR SYMBOL LETTERS GB", Score: 8487466},
-	{Char: "🔗", ID: "1F517", Name: "LINK SYMBOL", Score: 8486226},
-	{Char: "🌌", ID: "1F30C", Name: "MILKY WAY", Score: 8454482},
-	{Char: "🔔", ID: "1F514", Name: "BELL", Score: 8442978},
-	{Char: "↔️", ID: "2194", Name: "LEFT RIGHT ARROW", Score: 8430357},
-	{Char: "💧", ID: "1F4A7", Name: "DROPLET", Score: 8412357},
-	{Char: "®", ID: "00AE", Name: "REGISTERED SIGN", Score: 8361073},
-	{Char: "🐢", ID: "1F422", Name: "TURTLE", Score: 8292164},
-	{Char: "💉", ID: "1F489", Name: "SYRINGE", Score: 8127987},
-	{Char: "🍰", ID: "1F370", Name: "SHORTCAKE", Score: 8021720},
-	{Char: "🍔", ID: "1F354", Name: "HAMBURGER", Score: 7996020},
-	{Char: "🍬", ID: "1F36C", Name: "CANDY", Score: 7907711},
-	{Char: "⤵️", ID: "2935", Name: "ARROW POINTING RIGHTWARDS THEN CURVING DOWNWARDS", Score: 7894598},
-	{Char: "🏠", ID: "1F3E0", Name: "HOUSE BUILDING", Score: 7825026},
-	{Char: "👹", ID: "1F479", Name: "JAPANESE OGRE", Score: 7782014},
-	{Char: "3️⃣", ID: "0033-20E3", Name: "KEYCAP 3", Score: 7764465},
-	{Char: "👾", ID: "1F47E", Name: "ALIEN MONSTER", Score: 7677292},
-	{Char: "❓", ID: "2753", Name: "BLACK QUESTION MARK ORNAMENT", Score: 7634340},
-	{Char: "💵", ID: "1F4B5", Name: "BANKNOTE WITH DOLLAR SIGN", Score: 7621913},
-	{Char: "💄", ID: "1F484", Name: "LIPSTICK", Score: 7578449},
-	{Char: "🍦", ID: "1F366", Name: "SOFT ICE CREAM", Score: 7568032},
-	{Char: "⚾️", ID: "26BE", Name: "BASEBALL", Score: 7518662},
-	{Char: "🍎", ID: "1F34E", Name: "RED APPLE", Score: 7517186},
-	{Char: "🍇", ID: "1F347", Name: "GRAPES", Score: 7492733},
-	{Char: "🐒", ID: "1F412", Name: "MONKEY", Score: 7477086},
-	{Char: "👠", ID: "1F460", Name: "HIGH-HEELED SHOE", Score: 7426918},
-	{Char: "✂️", ID: "2702", Name: "BLACK SCISSORS", Score: 7405697},
-	{Char: "🇮🇹", ID: "1F1EE-1F1F9", Name: "REGIONAL INDICATOR SYMBOL LETTERS IT", Score: 7284449},
-	{Char: "🍸", ID: "1F378", Name: "COCKTAIL GLASS", Score: 7278943},
-	{Char: "👧", ID: "1F467", Name: "GIRL", Score: 7268698},
-	{Char: "🍪", ID: "1F36A", Name: "COOKIE", Score: 7251630},
-	{Char: "⚪️", ID: "26AA", Name: "MEDIUM WHITE CIRCLE", Score: 7220688},
-	{Char: "🇪🇸", ID: "1F1EA-1F1F8", Name: "REGIONAL INDICATOR SYMBOL LETTERS ES", Score: 7166324},
-	{Char: "🆒", ID: "1F192", Name: "SQUARED COOL", Score: 7127342},
-	{Char: "🐙", ID: "1F419", Name: "OCTOPUS", Score: 7115553},
-	{Char: "🍍", ID: "1F34D", Name: "PINEAPPLE", Score: 7105906},
-	{Char: "🛃", ID: "1F6C3", Name: "CUSTOMS", Score: 7103843},
-	{Char: "💊", ID: "1F48A", Name: "PILL", Score: 7077898},
-	{Char: "🍊", ID: "1F34A", Name: "TANGERINE", Score: 7042755},
-	{Char: "⚫️", ID: "26AB", Name: "MEDIUM BLACK CIRCLE", Score: 6833218},
-	{Char: "📖", ID: "1F4D6", Name: "OPEN BOOK", Score: 6808399},
-	{Char: "🌠", ID: "1F320", Name: "SHOOTING STAR", Score: 6746658},
-	{Char: "🎓", ID: "1F393", Name: "GRADUATION CAP", Score: 6704270},
-	{Char: "🔑", ID: "1F511", Name: "KEY", Score: 6632318},
-	{Char: "🐬", ID: "1F42C", Name: "DOLPHIN", Score: 6488851},
-	{Char: "🔷", ID: "1F537", Name: "LARGE BLUE DIAMOND", Score: 6476972},
-	{Char: "🆘", ID: "1F198", Name: "SQUARED SOS", Score: 6378407},
-	{Char: "😾", ID: "1F63E", Name: "POUTING CAT FACE", Score: 6357493},
-	{Char: "👙", ID: "1F459", Name: "BIKINI", Score: 6331321},
-	{Char: "🎆", ID: "1F386", Name: "FIREWORKS", Score: 6208637},
-	{Char: "🐦", ID: "1F426", Name: "BIRD", Score: 6200032},
-	{Char: "🔃", ID: "1F503", Name: "CLOCKWISE DOWNWARDS AND UPWARDS OPEN CIRCLE ARROWS", Score: 6093839},
-	{Char: "📕", ID: "1F4D5", Name: "CLOSED BOOK", Score: 6050344},
-	{Char: "💿", ID: "1F4BF", Name: "OPTICAL DISC", Score: 6030615},
-	{Char: "🆓", ID: "1F193", Name: "SQUARED FREE", Score: 6004608},
-	{Char: "🎨", ID: "1F3A8", Name: "ARTIST PALETTE", Score: 6000499},
-	{Char: "🍄", ID: "1F344", Name: "MUSHROOM", Score: 5974355},
-	{Char: "🏁", ID: "1F3C1", Name: "CHEQUERED FLAG", Score: 5929795},
-	{Char: "↘️", ID: "2198", Name: "SOUTH EAST ARROW", Score: 5802054},
-	{Char: "🔁", ID: "1F501", Name: "CLOCKWISE RIGHTWARDS AND LEFTWARDS OPEN CIRCLE ARROWS", Score: 5718736},
-	{Char: "👦", ID: "1F466", Name: "BOY", Score: 5717266},
-	{Char: "🔶", ID: "1F536", Name: "LARGE ORANGE DIAMOND", Score: 5645695},
-	{Char: "🗿", ID: "1F5FF", Name: "MOYAI", Score: 5640459},
-	{Char: "🏨", ID: "1F3E8", Name: "HOTEL", Score: 5604152},
-	{Char: "🎭", ID: "1F3AD", Name: "PERFORMING ARTS", Score: 5589055},
-	{Char: "↗️", ID: "2197", Name: "NORTH EAST ARROW", Score: 5478876},
-	{Char: "⬆️", ID: "2B06", Name: "UPWARDS BLACK ARROW", Score: 5476779},
-	{Char: "🌅", ID: "1F305", Name: "SUNRISE", Score: 5469716},
-	{Char: "🔻", ID: "1F53B", Name: "DOWN-POINTING RED TRIANGLE", Score: 5461619},
-	{Char: "4️⃣", ID: "0034-20E3", Name: "KEYCAP 4", Score: 5432177},
-	{Char: "⁉️", ID: "2049", Name: "EXCLAMATION QUESTION MARK", Score: 5407124},
-	{Char: "🍋", ID: "1F34B", Name: "LEMON", Score: 5345061},
-	{Char: "🌲", ID: "1F332", Name: "EVERGREEN TREE", Score: 5341392},
-	{Char: "🚩", ID: "1F6A9", Name: "TRIANGULAR FLAG ON POST", Score: 5339948},
-	{Char: "🐘", ID: "1F418", Name: "ELEPHANT", Score: 5333255},
-	{Char: "📥", ID: "1F4E5", Name: "INBOX TRAY", Score: 5260163},
-	{Char: "⏬", ID: "23EC", Name: "BLACK DOWN-POINTING DOUBLE TRIANGLE", Score: 5240050},
-	{Char: "⌚️", ID: "231A", Name: "WATCH", Score: 5224602},
-	{Char: "♋️", ID: "264B", Name: "CANCER", Score: 5216171},
-	{Char: "✉️", ID: "2709", Name: "ENVELOPE", Score: 5210431},
-	{Char: "↕️", ID: "2195", Name: "UP DOWN ARROW", Score: 5150768},
-	{Char: "🇩🇪", ID: "1F1E9-1F1EA", Name: "REGIONAL INDICATOR SYMBOL LETTERS DE", Score: 5133474},
-	{Char: "🐟", ID: "1F41F", Name: "FISH", Score: 5126186},
-	{Char: "👺", ID: "1F47A", Name: "JAPANESE GOBLIN", Score: 5112729},
-	{Char: "📩", ID: "1F4E9", Name: "ENVELOPE WITH DOWNWARDS ARROW ABOVE", Score: 5101316},
-	{Char: "🌃", ID: "1F303", Name: "NIGHT WITH STARS", Score: 5036970},
-	{Char: "🐺", ID: "1F43A", Name: "WOLF FACE", Score: 5024120},
-	{Char: "🔙", ID: "1F519", Name: "BACK WITH LEFTWARDS ARROW ABOVE", Score: 4970479},
-	{Char: "🔐", ID: "1F510", Name: "CLOSED LOCK WITH KEY", Score: 4937667},
-	{Char: "🏊", ID: "1F3CA", Name: "SWIMMER", Score: 4915444},
-	{Char: "🚘", ID: "1F698", Name: "ONCOMING AUTOMOBILE", Score: 4902467},
-	{Char: "📈", ID: "1F4C8", Name: "CHART WITH UPWARDS TREND", Score: 4882442},
-	{Char: "🔜", ID: "1F51C", Name: "SOON WITH RIGHTWARDS ARROW ABOVE", Score: 4872721},
-	{Char: "🆕", ID: "1F195", Name: "SQUARED NEW", Score: 4861706},
-	{Char: "🆚", ID: "1F19A", Name: "SQUARED VS", Score: 4844104},
-	{Char: "🍏", ID: "1F34F", Name: "GREEN APPLE", Score: 4829672},
-	{Char: "👗", ID: "1F457", Name: "DRESS", Score: 4787523},
-	{Char: "📰", ID: "1F4F0", Name: "NEWSPAPER", Score: 4736225},
-	{Char: "🌳", ID: "1F333", Name: "DECIDUOUS TREE", Score: 4686921},
-	{Char: "✳️", ID: "2733", Name: "EIGHT SPOKED ASTERISK", Score: 4681105},
-	{Char: "🍗", ID: "1F357", Name: "POULTRY LEG", Score: 4662669},
-	{Char: "🚦", ID: "1F6A6", Name: "VERTICAL TRAFFIC LIGHT", Score: 4655830},
-	{Char: "🐕", ID: "1F415", Name: "DOG", Score: 4619654},
-	{Char: "🎩", ID: "1F3A9", Name: "TOP HAT", Score: 4603912},
-	{Char: "⛔️", ID: "26D4", Name: "NO ENTRY", Score: 4556203},
-	{Char: "🎇", ID: "1F387", Name: "FIREWORK SPARKLER", Score: 4547864},
-	{Char: "🍼", ID: "1F37C", Name: "BABY BOTTLE", Score: 4538822},
-	{Char: "🐵", ID: "1F435", Name: "MONKEY FACE", Score: 4499888},
-	{Char: "❕", ID: "2755", Name: "WHITE EXCLAMATION MARK ORNAMENT", Score: 4498821},
-	{Char: "👪", ID: "1F46A", Name: "FAMILY", Score: 4489982},
-	{Char: "⏪", ID: "23EA", Name: "BLACK LEFT-POINTING DOUBLE TRIANGLE", Score: 4463711},
-	{Char: "5️⃣", ID: "0035-20E3", Name: "KEYCAP 5", Score: 4453051},
-	{Char: "🌆", ID: "1F306", Name: "CITYSCAPE AT DUSK", Score: 4425645},
-	{Char: "🌽", ID: "1F33D", Name: "EAR OF MAIZE", Score: 4381957},
-	{Char: "👓", ID: "1F453", Name: "EYEGLASSES", Score: 4366069},
-	{Char: "🔺", ID: "1F53A", Name: "UP-POINTING RED TRIANGLE", Score: 4355143},
-	{Char: "💂", ID: "1F482", Name: "GUARDSMAN", Score: 4347056},
-	{Char: "🔄", ID: "1F504", Name: "ANTICLOCKWISE DOWNWARDS AND UPWARDS OPEN CIRCLE ARROWS", Score: 4327635},
-	{Char: "🎾", ID: "1F3BE", Name: "TENNIS RACQUET AND BALL", Score: 4324771},
-	{Char: "👂", ID: "1F442", Name: "EAR", Score: 4260896},
-	{Char: "🌕", ID: "1F315", Name: "FULL MOON SYMBOL", Score: 4248410},
-	{Char: "🌛", ID: "1F31B", Name: "FIRST QUARTER MOON WITH FACE", Score: 4211227},
-	{Char: "👟", ID: "1F45F", Name: "ATHLETIC SHOE", Score: 4198977},
-	{Char: "💇", ID: "1F487", Name: "HAIRCUT", Score: 4156357},
-	{Char: "🐈", ID: "1F408", Name: "CAT", Score: 4144268},
-	{Char: "🐠", ID: "1F420", Name: "TROPICAL FISH", Score: 4101820},
-	{Char: "🌐", ID: "1F310", Name: "GLOBE WITH MERIDIANS", Score: 4083684},
-	{Char: "📆", ID: "1F4C6", Name: "TEAR-OFF CALENDAR", Score: 4072278},
-	{Char: "🐽", ID: "1F43D", Name: "PIG NOSE", Score: 4045218},
-	{Char: "🔘", ID: "1F518", Name: "RADIO BUTTON", Score: 4044870},
-	{Char: "↙️", ID: "2199", Name: "SOUTH WEST ARROW", Score: 4031430},
-	{Char: "🌑", ID: "1F311", Name: "NEW MOON SYMBOL", Score: 4004184},
-	{Char: "⛳️", ID: "26F3", Name: "FLAG IN HOLE", Score: 3964678},
-	{Char: "🍧", ID: "1F367", Name: "SHAVED ICE", Score: 3949552},
-	{Char: "👬", ID: "1F46C", Name: "TWO MEN HOLDING HANDS", Score: 3907109},
-	{Char: "🐨", ID: "1F428", Name: "KOALA", Score: 3881955},
-	{Char: "👵", ID: "1F475", Name: "OLDER WOMAN", Score: 3852198},
-	{Char: "🍯", ID: "1F36F", Name: "HONEY POT", Score: 3835158},
-	{Char: "🍳", ID: "1F373", Name: "COOKING", Score: 3832539},
-	{Char: "🆙", ID: "1F199", Name: "SQUARED UP WITH EXCLAMATION MARK", Score: 3829242},
-	{Char: "🍝", ID: "1F35D", Name: "SPAGHETTI", Score: 3808369},
-	{Char: "🐴", ID: "1F434", Name: "HORSE FACE", Score: 3804157},
-	{Char: "🍵", ID: "1F375", Name: "TEACUP WITHOUT HANDLE", Score: 3793601},
-	{Char: "🔍", ID: "1F50D", Name: "LEFT-POINTING MAGNIFYING GLASS", Score: 3790215},
-	{Char: "⛽️", ID: "26FD", Name: "FUEL PUMP", Score: 3788802},
-	{Char: "🍜", ID: "1F35C", Name: "STEAMING BOWL", Score: 3759222},
-	{Char: "🔎", ID: "1F50E", Name: "RIGHT-POINTING MAGNIFYING GLASS", Score: 3750956},
-	{Char: "🌜", ID: "1F31C", Name: "LAST QUARTER MOON WITH FACE", Score: 3749609},
-	{Char: "👰", ID: "1F470", Name: "BRIDE WITH VEIL", Score: 3733664},
-	{Char: "👤", ID: "1F464", Name: "BUST IN SILHOUETTE", Score: 3728582},
-	{Char: "⏳", ID: "23F3", Name: "HOURGLASS WITH FLOWING SAND", Score: 3723077},
-	{Char: "👃", ID: "1F443", Name: "NOSE", Score: 3714067},
-	{Char: "🐔", ID: "1F414", Name: "CHICKEN", Score: 3708658},
-	{Char: "🙎", ID: "1F64E", Name: "PERSON WITH POUTING FACE", Score: 3695938},
-	{Char: "✴️", ID: "2734", Name: "EIGHT POINTED BLACK STAR", Score: 3685117},
-	{Char: "🇯🇵", ID: "1F1EF-1F1F5", Name: "REGIONAL INDICATOR SYMBOL LETTERS JP", Score: 3679298},
-	{Char: "🐹", ID: "1F439", Name: "HAMSTER FACE", Score: 3643315},
-	{Char: "🛅", ID: "1F6C5", Name: "LEFT LUGGAGE", Score: 3642278},
-	{Char: "♐️", ID: "2650", Name: "SAGITTARIUS", Score: 3637015},
-	{Char: "💠", ID: "1F4A0", Name: "DIAMOND SHAPE WITH A DOT INSIDE", Score: 3634863},
-	{Char: "📦", ID: "1F4E6", Name: "PACKAGE", Score: 3623084},
-	{Char: "🌄", ID: "1F304", Name: "SUNRISE OVER MOUNTAINS", Score: 3607278},
-	{Char: "👕", ID: "1F455", Name: "T-SHIRT", Score: 3604261},
-	{Char: "❇️", ID: "2747", Name: "SPARKLE", Score: 3594290},
-	{Char: "↖️", ID: "2196", Name: "NORTH WEST ARROW", Score: 3561070},
-	{Char: "🎱", ID: "1F3B1", Name: "BILLIARDS", Score: 3520886},
-	{Char: "㊙️", ID: "3299", Name: "CIRCLED IDEOGRAPH SECRET", Score: 3507176},
-	{Char: "0️⃣", ID: "0030-20E3", Name: "KEYCAP 0", Score: 3441150},
-	{Char: "📅", ID: "1F4C5", Name: "CALENDAR", Score: 3440819},
-	{Char: "🍥", ID: "1F365", Name: "FISH CAKE WITH SWIRL DESIGN", Score: 3390380},
-	{Char: "👖", ID: "1F456", Name: "JEANS", Score: 3378915},
-	{Char: "🐮", ID: "1F42E", Name: "COW FACE", Score: 3357808},
-	{Char: "🍞", ID: "1F35E", Name: "BREAD", Score: 3344218},
-	{Char: "🐭", ID: "1F42D", Name: "MOUSE FACE", Score: 3332401},
-	{Char: "🔒", ID: "1F512", Name: "LOCK", Score: 3323242},
-	{Char: "🐞", ID: "1F41E", Name: "LADY BEETLE", Score: 3315155},
-	{Char: "🐎", ID: "1F40E", Name: "HORSE", Score: 3314136},
-	{Char: "➖", ID: "2796", Name: "HEAVY MINUS SIGN", Score: 3306573},
-	{Char: "🚿", ID: "1F6BF", Name: "SHOWER", Score: 3299050},
-	{Char: "🍅", ID: "1F345", Name: "TOMATO", Score: 3280574},
-	{Char: "📡", ID: "1F4E1", Name: "SATELLITE ANTENNA", Score: 3272331},
-	{Char: "🐉", ID: "1F409", Name: "DRAGON", Score: 3258489},
-	{Char: "➕", ID: "2795", Name: "HEAVY PLUS SIGN", Score: 3240121},
-	{Char: "🐛", ID: "1F41B", Name: "BUG", Score: 3218886},
-	{Char: "〰", ID: "3030", Name: "WAVY DASH", Score: 3202118},
-	{Char: "💲", ID: "1F4B2", Name: "HEAVY DOLLAR SIGN", Score: 3194300},
-	{Char: "➰", ID: "27B0", Name: "CURLY LOOP", Score: 3184949},
-	{Char: "🍣", ID: "1F363", Name: "SUSHI", Score: 3171483},
-	{Char: "🔰", ID: "1F530", Name: "JAPANESE SYMBOL FOR BEGINNER", Score: 3155880},
-	{Char: "👥", ID: "1F465", Name: "BUSTS IN SILHOUETTE", Score: 3154172},
-	{Char: "🚙", ID: "1F699", Name: "RECREATIONAL VEHICLE", Score: 3139880},
-	{Char: "🐑", ID: "1F411", Name: "SHEEP", Score: 3128833},
-	{Char: "👮", ID: "1F46E", Name: "POLICE OFFICER", Score: 3122947},
-	{Char: "Ⓜ️", ID: "24C2", Name: "CIRCLED LATIN CAPITAL LETTER M", Score: 3085160},
-	{Char: "👴", ID: "1F474", Name: "OLDER MAN", Score: 3040260},
-	{Char: "🚲", ID: "1F6B2", Name: "BICYCLE", Score: 3035217},
-	{Char: "🐇", ID: "1F407", Name: "RABBIT", Score: 2989678},
-	{Char: "🔽", ID: "1F53D", Name: "DOWN-POINTING SMALL RED TRIANGLE", Score: 2982502},
-	{Char: "🎐", ID: "1F390", Name: "WIND CHIME", Score: 2966338},
-	{Char: "🐤", ID: "1F424", Name: "BABY CHICK", Score: 2960513},
-	{Char: "🔱", ID: "1F531", Name: "TRIDENT EMBLEM", Score: 2959424},
-	{Char: "♈️", ID: "2648", Name: "ARIES", Score: 2899197},
-	{Char: "🔌", ID: "1F50C", Name: "ELECTRIC PLUG", Score: 2893126},
-	{Char: "⤴️", ID: "2934", Name: "ARROW POINTING RIGHTWARDS THEN CURVING UPWARDS", Score: 2880043},
-	{Char: "🔋", ID: "1F50B", Name: "BATTERY", Score: 2875063},
-	{Char: "🔆", ID: "1F506", Name: "HIGH BRIGHTNESS SYMBOL", Score: 2871165},
-	{Char: "🐓", ID: "1F413", Name: "ROOSTER", Score: 2861999},
-	{Char: "⛵️", ID: "26F5", Name: "SAILBOAT", Score: 2860734},
-	{Char: "🎹", ID: "1F3B9", Name: "MUSICAL KEYBOARD", Score: 2852627},
-	{Char: "✒️", ID: "2712", Name: "BLACK NIB", Score: 2850813},
-	{Char: "🐋", ID: "1F40B", Name: "WHALE", Score: 2842865},
-	{Char: "🔛", ID: "1F51B", Name: "ON WITH EXCLAMATION MARK WITH LEFT RIGHT ARROW ABOVE", Score: 2837431},
-	{Char: "㊗️", ID: "3297", Name: "CIRCLED IDEOGRAPH CONGRATULATION", Score: 2819986},
-	{Char: "⌛️", ID: "231B", Name: "HOURGLASS", Score: 2811998},
-	{Char: "🍱", ID: "1F371", Name: "BENTO BOX", Score: 2811951},
-	{Char: "🐊", ID: "1F40A", Name: "CROCODILE", Score: 2766499},
-	{Char: "🏄", ID: "1F3C4", Name: "SURFER", Score: 2765804},
-	{Char: "🅰", ID: "1F170", Name: "NEGATIVE SQUARED LATIN CAPITAL LETTER A", Score: 2762384},
-	{Char: "👳", ID: "1F473", Name: "MAN WITH TURBAN", Score: 2754766},
-	{Char: "🎣", ID: "1F3A3", Name: "FISHING POLE AND FISH", Score: 2746384},
-	{Char: "♒️", ID: "2652", Name: "AQUARIUS", Score: 2727304},
-	{Char: "♉️", ID: "2649", Name: "TAURUS", Score: 2717986},
-	{Char: "🎻", ID: "1F3BB", Name: "VIOLIN", Score: 2695633},
-	{Char: "🍤", ID: "1F364", Name: "FRIED SHRIMP", Score: 2689240},
-	{Char: "🍨", ID: "1F368", Name: "ICE CREAM", Score: 2687303},
-	{Char: "🆔", ID: "1F194", Name: "SQUARED ID", Score: 2655037},
-	{Char: "🇷🇺", ID: "1F1F7-1F1FA", Name: "REGIONAL INDICATOR SYMBOL LETTERS RU", Score: 2641548},
-	{Char: "♏️", ID: "264F", Name: "SCORPIUS", Score: 2631646},
-	{Char: "🎲", ID: "1F3B2", Name: "GAME DIE", Score: 2608976},
-	{Char: "🎎", ID: "1F38E", Name: "JAPANESE DOLLS", Score: 2583679},
-	{Char: "🅱", ID: "1F171", Name: "NEGATIVE SQUARED LATIN CAPITAL LETTER B", Score: 2572659},
-	{Char: "💮", ID: "1F4AE", Name: "WHITE FLOWER", Score: 2571242},
-	{Char: "🐌", ID: "1F40C", Name: "SNAIL", Score: 2568310},
-	{Char: "🎋", ID: "1F38B", Name: "TANABATA TREE", Score: 2529831},
-	{Char: "🚺", ID: "1F6BA", Name: "WOMENS SYMBOL", Score: 2527533},
-	{Char: "❎", ID: "274E", Name: "NEGATIVE SQUARED CROSS MARK", Score: 2525515},
-	{Char: "〽️", ID: "303D", Name: "PART ALTERNATION MARK", Score: 2511453},
-	{Char: "💒", ID: "1F492", Name: "WEDDING", Score: 2510632},
-	{Char: "🔓", ID: "1F513", Name: "OPEN LOCK", Score: 2508944},
-	{Char: "🍚", ID: "1F35A", Name: "COOKED RICE", Score: 2506267},
-	{Char: "🐀", ID: "1F400", Name: "RAT", Score: 2505238},
-	{Char: "🌒", ID: "1F312", Name: "WAXING CRESCENT MOON SYMBOL", Score: 2505087},
-	{Char: "🚌", ID: "1F68C", Name: "BUS", Score: 2446594},
-	{Char: "🚂", ID: "1F682", Name: "STEAM LOCOMOTIVE", Score: 2403674},
-	{Char: "6️⃣", ID: "0036-20E3", Name: "KEYCAP 6", Score: 2400226},
-	{Char: "👱", ID: "1F471", Name: "PERSON WITH BLOND HAIR", Score: 2398995},
-	{Char: "🔨", ID: "1F528", Name: "HAMMER", Score: 2387163},
-	{Char: "🗽", ID: "1F5FD", Name: "STATUE OF LIBERTY", Score: 2383924},
-	{Char: "🐚", ID: "1F41A", Name: "SPIRAL SHELL", Score: 2382702},
-	{Char: "🍖", ID: "1F356", Name: "MEAT ON BONE", Score: 2368851},
-	{Char: "🌓", ID: "1F313", Name: "FIRST QUARTER MOON SYMBOL", Score: 2366982},
-	{Char: "🌋", ID: "1F30B", Name: "VOLCANO", Score: 2366145},
-	{Char: "🚼", ID: "1F6BC", Name: "BABY SYMBOL", Score: 2348944},
-	{Char: "🌘", ID: "1F318", Name: "WANING CRESCENT MOON SYMBOL", Score: 2318675},
-	{Char: "📊", ID: "1F4CA", Name: "BAR CHART", Score: 2296005},
-	{Char: "♌️", ID: "264C", Name: "LEO", Score: 2294276},
-	{Char: "👚", ID: "1F45A", Name: "WOMANS CLOTHES", Score: 2284994},
-	{Char: "▫️", ID: "25AB", Name: "WHITE SMALL SQUARE", Score: 2274562},
-	{Char: "♊️", ID: "264A", Name: "GEMINI", Score: 2271811},
-	{Char: "♓️", ID: "2653", Name: "PISCES", Score: 2267774},
-	{Char: "🕕", ID: "1F555", Name: "CLOCK FACE SIX OCLOCK", Score: 2259136},
-	{Char: "🍐", ID: "1F350", Name: "PEAR", Score: 2215546},
-	{Char: "🐩", ID: "1F429", Name: "POODLE", Score: 2207349},
-	{Char: "🐜", ID: "1F41C", Name: "ANT", Score: 2207066},
-	{Char: "🚴", ID: "1F6B4", Name: "BICYCLIST", Score: 2180223},
-	{Char: "7️⃣", ID: "0037-20E3", Name: "KEYCAP 7", Score: 2179184},
-	{Char: "🌔", ID: "1F314", Name: "WAXING GIBBOUS MOON SYMBOL", Score: 2169437},
-	{Char: "🐖", ID: "1F416", Name: "PIG", Score: 2158776},
-	{Char: "💳", ID: "1F4B3", Name: "CREDIT CARD", Score: 2148017},
-	{Char: "⛪️", ID: "26EA", Name: "CHURCH", Score: 2146920},
-	{Char: "🚚", ID: "1F69A", Name: "DELIVERY TRUCK", Score: 2133172},
-	{Char: "🌗", ID: "1F317", Name: "LAST QUARTER MOON SYMBOL", Score: 2119394},
-	{Char: "📉", ID: "1F4C9", Name: "CHART WITH DOWNWARDS TREND", Score: 2084021},
-	{Char: "🕛", ID: "1F55B", Name: "CLOCK FACE TWELVE OCLOCK", Score: 2066137},
-	{Char: "ℹ️", ID: "2139", Name: "INFORMATION SOURCE", Score: 2062621},
-	{Char: "📛", ID: "1F4DB", Name: "NAME BADGE", Score: 2044998},
-	{Char: "📀", ID: "1F4C0", Name: "DVD", Score: 2044883},
-	{Char: "🏢", ID: "1F3E2", Name: "OFFICE BUILDING", Score: 2026253},
-	{Char: "🏫", ID: "1F3EB", Name: "SCHOOL", Score: 2020587},
-	{Char: "👔", ID: "1F454", Name: "NECKTIE", Score: 2016818},
-	{Char: "🇰🇷", ID: "1F1F0-1F1F7", Name: "REGIONAL INDICATOR SYMBOL LETTERS KR", Score: 2015728},
-	{Char: "📋", ID: "1F4CB", Name: "CLIPBOARD", Score: 2013365},
-	{Char: "🌇", ID: "1F307", Name: "SUNSET OVER BUILDINGS", Score: 2005468},
-	{Char: "♑️", ID: "2651", Name: "CAPRICORN", Score: 1989546},
-	{Char: "🕐", ID: "1F550", Name: "CLOCK FACE ONE OCLOCK", Score: 1988671},
-	{Char: "🍲", ID: "1F372", Name: "POT OF FOOD", Score: 1978396},
-	{Char: "🏥", ID: "1F3E5", Name: "HOSPITAL", Score: 1958360},
-	{Char: "🏩", ID: "1F3E9", Name: "LOVE HOTEL", Score: 1953891},
-	{Char: "🌖", ID: "1F316", Name: "WANING GIBBOUS MOON SYMBOL", Score: 1930705},
-	{Char: "🔉", ID: "1F509", Name: "SPEAKER WITH ONE SOUND WAVE", Score: 1930410},
-	{Char: "🏪", ID: "1F3EA", Name: "CONVENIENCE STORE", Score: 1929681},
-	{Char: "🍮", ID: "1F36E", Name: "CUSTARD", Score: 1929605},
-	{Char: "🛀", ID: "1F6C0", Name: "BATH", Score: 1920365},
-	{Char: "🐲", ID: "1F432", Name: "DRAGON FACE", Score: 1920089},
-	{Char: "📄", ID: "1F4C4", Name: "PAGE FACING UP", Score: 1909222},
-	{Char: "🔈", ID: "1F508", Name: "SPEAKER", Score: 1900614},
-	{Char: "🌰", ID: "1F330", Name: "CHESTNUT", Score: 1897699},
-	{Char: "🏉", ID: "1F3C9", Name: "RUGBY FOOTBALL", Score: 1895579},
-	{Char: "👢", ID: "1F462", Name: "WOMANS BOOTS", Score: 1886780},
-	{Char: "🚑", ID: "1F691", Name: "AMBULANCE", Score: 1884922},
-	{Char: "🍛", ID: "1F35B", Name: "CURRY AND RICE", Score: 1873101},
-	{Char: "🈹", ID: "1F239", Name: "SQUARED CJK UNIFIED IDEOGRAPH-5272", Score: 1871958},
-	{Char: "♍️", ID: "264D", Name: "VIRGO", Score: 1871839},
-	{Char: "📮", ID: "1F4EE", Name: "POSTBOX", Score: 1870549},
-	{Char: "🎷", ID: "1F3B7", Name: "SAXOPHONE", Score: 1837148},
-	{Char: "🏂", ID: "1F3C2", Name: "SNOWBOARDER", Score: 1836281},
-	{Char: "🐄", ID: "1F404", Name: "COW", Score: 1835265},
-	{Char: "🅾", ID: "1F17E", Name: "NEGATIVE SQUARED LATIN CAPITAL LETTER O", Score: 1830963},
-	{Char: "🚔", ID: "1F694", Name: "ONCOMING POLICE CAR", Score: 1825252},
-	{Char: "🎪", ID: "1F3AA", Name: "CIRCUS TENT", Score: 1814631},
-	{Char: "♎️", ID: "264E", Name: "LIBRA", Score: 1810696},
-	{Char: "🇨🇳", ID: "1F1E8-1F1F3", Name: "REGIONAL INDICATOR SYMBOL LETTERS CN", Score: 1794577},
-	{Char: "🎺", ID: "1F3BA", Name: "TRUMPET", Score: 1792882},
-	{Char: "💼", ID: "1F4BC", Name: "BRIEFCASE", Score: 1768194},
-	{Char: "🚪", ID: "1F6AA", Name: "DOOR", Score: 1765920},
-	{Char: "🏇", ID: "1F3C7", Name: "HORSE RACING", Score: 1759412},
-	{Char: "❔", ID: "2754", Name: "WHITE QUESTION MARK ORNAMENT", Score: 1732572},
-	{Char: "🍡", ID: "1F361", Name: "DANGO", Score: 1703380},
-	{Char: "🎰", ID: "1F3B0", Name: "SLOT MACHINE", Score: 1694887},
-	{Char: "🅿️", ID: "1F17F", Name: "NEGATIVE SQUARED LATIN CAPITAL LETTER P", Score: 1694514},
-	{Char: "🚓", ID: "1F693", Name: "POLICE CAR", Score: 1679435},
-	{Char: "👲", ID: "1F472", Name: "MAN WITH GUA PI MAO", Score: 1677925},
-	{Char: "🐡", ID: "1F421", Name: "BLOWFISH", Score: 1655111},
-	{Char: "🎫", ID: "1F3AB", Name: "TICKET", Score: 1654100},
-	{Char: "👞", ID: "1F45E", Name: "MANS SHOE", Score: 1630448},
-	{Char: "🎡", ID: "1F3A1", Name: "FERRIS WHEEL", Score: 1622549},
-	{Char: "👜", ID: "1F45C", Name: "HANDBAG", Score: 1611596},
-	{Char: "🐅", ID: "1F405", Name: "TIGER", Score: 1609648},
-	{Char: "👒", ID: "1F452", Name: "WOMANS HAT", Score: 1607865},
-	{Char: "🕒", ID: "1F552", Name: "CLOCK FACE THREE OCLOCK", Score: 1598062},
-	{Char: "🚽", ID: "1F6BD", Name: "TOILET", Score: 1594956},
-	{Char: "🐂", ID: "1F402", Name: "OX", Score: 1587730},
-	{Char: "🏰", ID: "1F3F0", Name: "EUROPEAN CASTLE", Score: 1582647},
-	{Char: "🎒", ID: "1F392", Name: "SCHOOL SATCHEL", Score: 1569681},
-	{Char: "🗻", ID: "1F5FB", Name: "MOUNT FUJI", Score: 1565296},
-	{Char: "🃏", ID: "1F0CF", Name: "PLAYING CARD BLACK JOKER", Score: 1562084},
-	{Char: "🚢", ID: "1F6A2", Name: "SHIP", Score: 1560605},
-	{Char: "🚒", ID: "1F692", Name: "FIRE ENGINE", Score: 1539349},
-	{Char: "🔕", ID: "1F515", Name: "BELL WITH CANCELLATION STROKE", Score: 1533353},
-	{Char: "💈", ID: "1F488", Name: "BARBER POLE", Score: 1531050},
-	{Char: "🐏", ID: "1F40F", Name: "RAM", Score: 1529972},
-	{Char: "9️⃣", ID: "0039-20E3", Name: "KEYCAP 9", Score: 1506268},
-	{Char: "8️⃣", ID: "0038-20E3", Name: "KEYCAP 8", Score: 1504425},
-	{Char: "🍈", ID: "1F348", Name: "MELON", Score: 1499132},
-	{Char: "🌂", ID: "1F302", Name: "CLOSED UMBRELLA", Score: 1495592},
-	{Char: "🎳", ID: "1F3B3", Name: "BOWLING", Score: 1482701},
-	{Char: "💷", ID: "1F4B7", Name: "BANKNOTE WITH POUND SIGN", Score: 1477511},
-	{Char: "💶", ID: "1F4B6", Name: "BANKNOTE WITH EURO SIGN", Score: 1476410},
-	{Char: "📎", ID: "1F4CE", Name: "PAPERCLIP", Score: 1463101},
-	{Char: "💴", ID: "1F4B4", Name: "BANKNOTE WITH YEN SIGN", Score: 1461524},
-	{Char: "🐫", ID: "1F42B", Name: "BACTRIAN CAMEL", Score: 1436669},
-	{Char: "🎢", ID: "1F3A2", Name: "ROLLER COASTER", Score: 1429744},
-	{Char: "🔅", ID: "1F505", Name: "LOW BRIGHTNESS SYMBOL", Score: 1415361},
-	{Char: "🚧", ID: "1F6A7", Name: "CONSTRUCTION SIGN", Score: 1413831},
-	{Char: "🎌", ID: "1F38C", Name: "CROSSED FLAGS", Score: 1411067},
-	{Char: "🍙", ID: "1F359", Name: "RICE BALL", Score: 1406553},
-	{Char: "🐪", ID: "1F42A", Name: "DROMEDARY CAMEL", Score: 1392901},
-	{Char: "🔼", ID: "1F53C", Name: "UP-POINTING SMALL RED TRIANGLE", Score: 1375767},
-	{Char: "🚁", ID: "1F681", Name: "HELICOPTER", Score: 1370803},
-	{Char: "◾️", ID: "25FE", Name: "BLACK MEDIUM SMALL SQUARE", Score: 1365869},
-	{Char: "🚕", ID: "1F695", Name: "TAXI", Score: 1359037},
-	{Char: "🎍", ID: "1F38D", Name: "PINE DECORATION", Score: 1353810},
-	{Char: "👷", ID: "1F477", Name: "CONSTRUCTION WORKER", Score: 1348289},
-	{Char: "🗼", ID: "1F5FC", Name: "TOKYO TOWER", Score: 1325640},
-	{Char: "📨", ID: "1F4E8", Name: "INCOMING ENVELOPE", Score: 1324871},
-	{Char: "👛", ID: "1F45B", Name: "PURSE", Score: 1315025},
-	{Char: "⛺️", ID: "26FA", Name: "TENT", Score: 1303316},
-	{Char: "🚣", ID: "1F6A3", Name: "ROWBOAT", Score: 1300401},
-	{Char: "🔚", ID: "1F51A", Name: "END WITH LEFTWARDS ARROW ABOVE", Score: 1282678},
-	{Char: "🎠", ID: "1F3A0", Name: "CAROUSEL HORSE", Score: 1244796},
-	{Char: "🍠", ID: "1F360", Name: "ROASTED SWEET POTATO", Score: 1243883},
-	{Char: "📬", ID: "1F4EC", Name: "OPEN MAILBOX WITH RAISED FLAG", Score: 1239863},
-	{Char: "🌁", ID: "1F301", Name: "FOGGY", Score: 1227424},
-	{Char: "⬜️", ID: "2B1C", Name: "WHITE LARGE SQUARE", Score: 1226160},
-	{Char: "💱", ID: "1F4B1", Name: "CURRENCY EXCHANGE", Score: 1213527},
-	{Char: "🔲", ID: "1F532", Name: "BLACK SQUARE BUTTON", Score: 1201811},
-	{Char: "👘", ID: "1F458", Name: "KIMONO", Score: 1201761},
-	{Char: "🐗", ID: "1F417", Name: "BOAR", Score: 1178865},
-	{Char: "🐃", ID: "1F403", Name: "WATER BUFFALO", Score: 1175839},
-	{Char: "🔟", ID: "1F51F", Name: "KEYCAP TEN", Score: 1169080},
-	{Char: "💽", ID: "1F4BD", Name: "MINIDISC", Score: 1162282},
-	{Char: "📴", ID: "1F4F4", Name: "MOBILE PHONE OFF", Score: 1157219},
-	{Char: "🔦", ID: "1F526", Name: "ELECTRIC TORCH", Score: 1134022},
-	{Char: "♿️", ID: "267F", Name: "WHEELCHAIR SYMBOL", Score: 1130170},
-	{Char: "🚹", ID: "1F6B9", Name: "MENS SYMBOL", Score: 1127080},
-	{Char: "➿", ID: "27BF", Name: "DOUBLE CURLY LOOP", Score: 1121865},
-	{Char: "🕑", ID: "1F551", Name: "CLOCK FACE TWO OCLOCK", Score: 1114782},
-	{Char: "🔖", ID: "1F516", Name: "BOOKMARK", Score: 1112174},
-	{Char: "🎏", ID: "1F38F", Name: "CARP STREAMER", Score: 1105248},
-	{Char: "🌉", ID: "1F309", Name: "BRIDGE AT NIGHT", Score: 1092176},
-	{Char: "⏫", ID: "23EB", Name: "BLACK UP-POINTING DOUBLE TRIANGLE", Score: 1089068},
-	{Char: "⬛️", ID: "2B1B", Name: "BLACK LARGE SQUARE", Score: 1049671},
-	{Char: "📧", ID: "1F4E7", Name: "E-MAIL SYMBOL", Score: 1048904},
-	{Char: "🎑", ID: "1F391", Name: "MOON VIEWING CEREMONY", Score: 1048837},
-	{Char: "◼️", ID: "25FC", Name: "BLACK MEDIUM SQUARE", Score: 1038394},
-	{Char: "🚍", ID: "1F68D", Name: "ONCOMING BUS", Score: 1037219},
-	{Char: "🕓", ID: "1F553", Name: "CLOCK FACE FOUR OCLOCK", Score: 1033337},
-	{Char: "🔭", ID: "1F52D", Name: "TELESCOPE", Score: 1031699},
-	{Char: "🐆", ID: "1F406", Name: "LEOPARD", Score: 1028039},
-	{Char: "💺", ID: "1F4BA", Name: "SEAT", Score: 1015679},
-	{Char: "📶", ID: "1F4F6", Name: "ANTENNA WITH BARS", Score: 1007747},
-	{Char: "📼", ID: "1F4FC", Name: "VIDEOCASSETTE", Score: 1004027},
-	{Char: "🏦", ID: "1F3E6", Name: "BANK", Score: 1003124},
-	{Char: "🚛", ID: "1F69B", Name: "ARTICULATED LORRY", Score: 1002109},
-	{Char: "#️⃣", ID: "0023-20E3", Name: "HASH KEY", Score: 1001773},
-	{Char: "🔳", ID: "1F533", Name: "WHITE SQUARE BUTTON", Score: 974771},
-	{Char: "🏬", ID: "1F3EC", Name: "DEPARTMENT STORE", Score: 959088},
-	{Char: "👡", ID: "1F461", Name: "WOMANS SANDAL", Score: 956617},
-	{Char: "🔂", ID: "1F502", Name: "CLOCKWISE RIGHTWARDS AND LEFTWARDS OPEN CIRCLE ARROWS WITH CIRCLED ONE OVERLAY", Score: 951449},
-	{Char: "🕘", ID: "1F558", Name: "CLOCK FACE NINE OCLOCK", Score: 944003},
-	{Char: "📃", ID: "1F4C3", Name: "PAGE WITH CURL", Score: 943598},
-	{Char: "📒", ID: "1F4D2", Name: "LEDGER", Score: 942913},
-	{Char: "📓", ID: "1F4D3", Name: "NOTEBOOK", Score: 935955},
-	{Char: "💾", ID: "1F4BE", Name: "FLOPPY DISK", Score: 933484},
-	{Char: "🔯", ID: "1F52F", Name: "SIX POINTED STAR WITH MIDDLE DOT", Score: 915661},
-	{Char: "🔧", ID: "1F527", Name: "WRENCH", Score: 914544},
-	{Char: "🕥", ID: "1F565", Name: "CLOCK FACE TEN-THIRTY", Score: 914038},
-	{Char: "⛲️", ID: "26F2", Name: "FOUNTAIN", Score: 896678},
-	{Char: "📜", ID: "1F4DC", Name: "SCROLL", Score: 888575},
-	{Char: "🕗", ID: "1F557", Name: "CLOCK FACE EIGHT OCLOCK", Score: 879234},
-	{Char: "🏧", ID: "1F3E7", Name: "AUTOMATED TELLER MACHINE", Score: 874910},
-	{Char: "🏤", ID: "1F3E4", Name: "EUROPEAN POST OFFICE", Score: 870720},
-	{Char: "🔬", ID: "1F52C", Name: "MICROSCOPE", Score: 870620},
-	{Char: "📗", ID: "1F4D7", Name: "GREEN BOOK", Score: 868334},
-	{Char: "🔇", ID: "1F507", Name: "SPEAKER WITH CANCELLATION STROKE", Score: 863841},
-	{Char: "🍢", ID: "1F362", Name: "ODEN", Score: 858658},
-	{Char: "📘", ID: "1F4D8", Name: "BLUE BOOK", Score: 856926},
-	{Char: "🛁", ID: "1F6C1", Name: "BATHTUB", Score: 854337},
-	{Char: "🕔", ID: "1F554", Name: "CLOCK FACE FIVE OCLOCK", Score: 847102},
-	{Char: "🕙", ID: "1F559", Name: "CLOCK FACE TEN OCLOCK", Score: 842808},
-	{Char: "🕖", ID: "1F556", Name: "CLOCK FACE SEVEN OCLOCK", Score: 838765},
-	{Char: "🍶", ID: "1F376", Name: "SAKE BOTTLE AND CUP", Score: 835424},
-	{Char: "🚄", ID: "1F684", Name: "HIGH-SPEED TRAIN", Score: 830106},
-	{Char: "🚵", ID: "1F6B5", Name: "MOUNTAIN BICYCLIST", Score: 819722},
-	{Char: "🕦", ID: "1F566", Name: "CLOCK FACE ELEVEN-THIRTY", Score: 812772},
-	{Char: "🍘", ID: "1F358", Name: "RICE CRACKER", Score: 805701},
-	{Char: "🎽", ID: "1F3BD", Name: "RUNNING SHIRT WITH SASH", Score: 802193},
-	{Char: "🐁", ID: "1F401", Name: "MOUSE", Score: 801948},
-	{Char: "🚜", ID: "1F69C", Name: "TRACTOR", Score: 785472},
-	{Char: "🎿", ID: "1F3BF", Name: "SKI AND SKI BOOT", Score: 774972},
-	{Char: "🚃", ID: "1F683", Name: "RAILWAY CAR", Score: 770610},
-	{Char: "⛎", ID: "26CE", Name: "OPHIUCHUS", Score: 764109},
-	{Char: "🚤", ID: "1F6A4", Name: "SPEEDBOAT", Score: 755143},
-	{Char: "◻️", ID: "25FB", Name: "WHITE MEDIUM SQUARE", Score: 751215},
-	{Char: "🏮", ID: "1F3EE", Name: "IZAKAYA LANTERN", Score: 743065},
-	{Char: "🎦", ID: "1F3A6", Name: "CINEMA", Score: 742575},
-	{Char: "🉐", ID: "1F250", Name: "CIRCLED IDEOGRAPH ADVANTAGE", Score: 721583},
-	{Char: "🚐", ID: "1F690", Name: "MINIBUS", Score: 721411},
-	{Char: "📏", ID: "1F4CF", Name: "STRAIGHT RULER", Score: 721263},
-	{Char: "💹", ID: "1F4B9", Name: "CHART WITH UPWARDS TREND AND YEN SIGN", Score: 714229},
-	{Char: "🕚", ID: "1F55A", Name: "CLOCK FACE ELEVEN OCLOCK", Score: 711640},
-	{Char: "🚥", ID: "1F6A5", Name: "HORIZONTAL TRAFFIC LIGHT", Score: 710110},
-	{Char: "◽️", ID: "25FD", Name: "WHITE MEDIUM SMALL SQUARE", Score: 695277},
-	{Char: "🚇", ID: "1F687", Name: "METRO", Score: 647722},
-	{Char: "📵", ID: "1F4F5", Name: "NO MOBILE PHONES", Score: 637051},
-	{Char: "📳", ID: "1F4F3", Name: "VIBRATION MODE", Score: 635505},
-	{Char: "📠", ID: "1F4E0", Name: "FAX MACHINE", Score: 626948},
-	{Char: "🏭", ID: "1F3ED", Name: "FACTORY", Score: 626145},
-	{Char: "🚆", ID: "1F686", Name: "TRAIN", Score: 622633},
-	{Char: "🚭", ID: "1F6AD", Name: "NO SMOKING SYMBOL", Score: 615754},
-	{Char: "🚅", ID: "1F685", Name: "HIGH-SPEED TRAIN WITH BULLET NOSE", Score: 615353},
-	{Char: "📙", ID: "1F4D9", Name: "ORANGE BOOK", Score: 587673},
-	{Char: "🕞", ID: "1F55E", Name: "CLOCK FACE THREE-THIRTY", Score: 575827},
-	{Char: "🚋", ID: "1F68B", Name: "TRAM CAR", Score: 569081},
-	{Char: "🔀", ID: "1F500", Name: "TWISTED RIGHTWARDS ARROWS", Score: 560471},
-	{Char: "🏣", ID: "1F3E3", Name: "JAPANESE POST OFFICE", Score: 560328},
-	{Char: "🏯", ID: "1F3EF", Name: "JAPANESE CASTLE", Score: 545024},
-	{Char: "📑", ID: "1F4D1", Name: "BOOKMARK TABS", Score: 543065},
-	{Char: "🚉", ID: "1F689", Name: "STATION", Score: 534157},
-	{Char: "🚈", ID: "1F688", Name: "LIGHT RAIL", Score: 530796},
-	{Char: "🚊", ID: "1F68A", Name: "TRAM", Score: 525867},
-	{Char: "🚎", ID: "1F68E", Name: "TROLLEYBUS", Score: 521703},
-	{Char: "🔩", ID: "1F529", Name: "NUT AND BOLT", Score: 516813},
-	{Char: "🎴", ID: "1F3B4", Name: "FLOWER PLAYING CARDS", Score: 515124},
-	{Char: "🚾", ID: "1F6BE", Name: "WATER CLOSET", Score: 510377},
-	{Char: "📯", ID: "1F4EF", Name: "POSTAL HORN", Score: 492999},
-	{Char: "📫", ID: "1F4EB", Name: "CLOSED MAILBOX WITH RAISED FLAG", Score: 492971},
-	{Char: "📂", ID: "1F4C2", Name: "OPEN FILE FOLDER", Score: 492119},
-	{Char: "🆎", ID: "1F18E", Name: "NEGATIVE SQUARED AB", Score: 488438},
-	{Char: "🆖", ID: "1F196", Name: "SQUARED NG", Score: 488413},
-	{Char: "🚷", ID: "1F6B7", Name: "NO PEDESTRIANS", Score: 482178},
-	{Char: "📐", ID: "1F4D0", Name: "TRIANGULAR RULER", Score: 467820},
-	{Char: "📔", ID: "1F4D4", Name: "NOTEBOOK WITH DECORATIVE COVER", Score: 462080},
-	{Char: "📤", ID: "1F4E4", Name: "OUTBOX TRAY", Score: 440833},
-	{Char: "🈯️", ID: "1F22F", Name: "SQUARED CJK UNIFIED IDEOGRAPH-6307", Score: 440385},
-	{Char: "🚳", ID: "1F6B3", Name: "NO BICYCLES", Score: 433008},
-	{Char: "📟", ID: "1F4DF", Name: "PAGER", Score: 414986},
-	{Char: "🔢", ID: "1F522", Name: "INPUT SYMBOL FOR NUMBERS", Score: 414674},
-	{Char: "🚻", ID: "1F6BB", Name: "RESTROOM", Score: 405770},
-	{Char: "📁", ID: "1F4C1", Name: "FILE FOLDER", Score: 404175},
-	{Char: "🚟", ID: "1F69F", Name: "SUSPENSION RAILWAY", Score: 401458},
-	{Char: "🚸", ID: "1F6B8", Name: "CHILDREN CROSSING", Score: 399577},
-	{Char: "➗", ID: "2797", Name: "HEAVY DIVISION SIGN", Score: 398371},
-	{Char: "🔏", ID: "1F50F", Name: "LOCK WITH INK PEN", Score: 397157},
-	{Char: "🚏", ID: "1F68F", Name: "BUS STOP", Score: 387714},
-	{Char: "👝", ID: "1F45D", Name: "POUCH", Score: 380701},
-	{Char: "🀄️", ID: "1F004", Name: "MAHJONG TILE RED DRAGON", Score: 375353},
-	{Char: "🕤", ID: "1F564", Name: "CLOCK FACE NINE-THIRTY", Score: 373005},
-	{Char: "🚡", ID: "1F6A1", Name: "AERIAL TRAMWAY", Score: 372772},
-	{Char: "🗾", ID: "1F5FE", Name: "SILHOUETTE OF JAPAN", Score: 362748},
-	{Char: "🚞", ID: "1F69E", Name: "MOUNTAIN RAILWAY", Score: 361991},
-	{Char: "🕜", ID: "1F55C", Name: "CLOCK FACE ONE-THIRTY", Score: 361942},
-	{Char: "🈁", ID: "1F201", Name: "SQUARED KATAKANA KOKO", Score: 355490},
-	{Char: "🆑", ID: "1F191", Name: "SQUARED CL", Score: 347953},
-	{Char: "🈳", ID: "1F233", Name: "SQUARED CJK UNIFIED IDEOGRAPH-7A7A", Score: 343245},
-	{Char: "🚠", ID: "1F6A0", Name: "MOUNTAIN CABLEWAY", Score: 333760},
-	{Char: "🕝", ID: "1F55D", Name: "CLOCK FACE TWO-THIRTY", Score: 333585},
-	{Char: "🛄", ID: "1F6C4", Name: "BAGGAGE CLAIM", Score: 330344},
-	{Char: "📪", ID: "1F4EA", Name: "CLOSED MAILBOX WITH LOWERED FLAG", Score: 319732},
-	{Char: "🕢", ID: "1F562", Name: "CLOCK FACE SEVEN-THIRTY", Score: 318566},
-	{Char: "🈺", ID: "1F23A", Name: "SQUARED CJK UNIFIED IDEOGRAPH-55B6", Score: 313232},
-	{Char: "🕣", ID: "1F563", Name: "CLOCK FACE EIGHT-THIRTY", Score: 310201},
-	{Char: "🈂", ID: "1F202", Name: "SQUARED KATAKANA SA", Score: 310118},
-	{Char: "🈵", ID: "1F235", Name: "SQUARED CJK UNIFIED IDEOGRAPH-6E80", Score: 309148},
-	{Char: "🕟", ID: "1F55F", Name: "CLOCK FACE FOUR-THIRTY", Score: 308538},
-	{Char: "🈶", ID: "1F236", Name: "SQUARED CJK UNIFIED IDEOGRAPH-6709", Score: 302589},
-	{Char: "🈲", ID: "1F232", Name: "SQUARED CJK UNIFIED IDEOGRAPH-7981", Score: 294623},
-	{Char: "🕠", ID: "1F560", Name: "CLOCK FACE FIVE-THIRTY", Score: 291295},
-	{Char: "🉑", ID: "1F251", Name: "CIRCLED IDEOGRAPH ACCEPT", Score: 289630},
-	{Char: "🈚️", ID: "1F21A", Name: "SQUARED CJK UNIFIED IDEOGRAPH-7121", Score: 289449},
-	{Char: "🚰", ID: "1F6B0", Name: "POTABLE WATER SYMBOL", Score: 287695},
-	{Char: "📭", ID: "1F4ED", Name: "OPEN MAILBOX WITH LOWERED FLAG", Score: 283087},
-	{Char: "🕡", ID: "1F561", Name: "CLOCK FACE SIX-THIRTY", Score: 275959},
-	{Char: "🈷", ID: "1F237", Name: "SQUARED CJK UNIFIED IDEOGRAPH-6708", Score: 268659},
-	{Char: "🈴", ID: "1F234", Name: "SQUARED CJK UNIFIED IDEOGRAPH-5408", Score: 261397},
-	{Char: "🚝", ID: "1F69D", Name: "MONORAIL", Score: 260951},
-	{Char: "🕧", ID: "1F567", Name: "CLOCK FACE TWELVE-THIRTY", Score: 257739},
-	{Char: "🈸", ID: "1F238", Name: "SQUARED CJK UNIFIED IDEOGRAPH-7533", Score: 252283},
-	{Char: "🔠", ID: "1F520", Name: "INPUT SYMBOL FOR LATIN CAPITAL LETTERS", Score: 246373},
-	{Char: "🛂", ID: "1F6C2", Name: "PASSPORT CONTROL", Score: 238673},
-	{Char: "📇", ID: "1F4C7", Name: "CARD INDEX", Score: 238286},
-	{Char: "🚯", ID: "1F6AF", Name: "DO NOT LITTER SYMBOL", Score: 234106},
-	{Char: "🚱", ID: "1F6B1", Name: "NON-POTABLE WATER SYMBOL", Score: 227102},
-	{Char: "🔡", ID: "1F521", Name: "INPUT SYMBOL FOR LATIN SMALL LETTERS", Score: 223304},
-	{Char: "🔤", ID: "1F524", Name: "INPUT SYMBOL FOR LATIN LETTERS", Score: 217229},
-	{Char: "🔣", ID: "1F523", Name: "INPUT SYMBOL FOR SYMBOLS", Score: 205263},
+// Snapshots returns the names of all bundled snapshots, in sorted order.
+func Snapshots() []string {
+	entries, _ := fs.ReadDir(snapshotFiles, snapshotDir)
+	var names []string
+	for _, e := range entries {
+		if name, ok := strings.CutSuffix(e.Name(), ".json.gz"); ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NamedSnapshot returns the bundled snapshot of rankings with the given name
+// (see Snapshots), e.g. "2020".
+func NamedSnapshot(name string) ([]fakefeeder.Ranking, error) {
+	snapshotMu.Lock()
+	defer snapshotMu.Unlock()
+
+	rs, ok := snapshotCache[name]
+	if !ok {
+		sf, err := readSnapshot(name)
+		if err != nil {
+			return nil, err
+		}
+		rs = sf.Rankings
+		snapshotCache[name] = rs
+	}
+	return rs, nil
+}
+
+// NamedSnapshotFile returns the bundled snapshot with the given name in full,
+// including where and when its rankings were obtained.
+func NamedSnapshotFile(name string) (*SnapshotFile, error) {
+	return readSnapshot(name)
+}
+
+func readSnapshot(name string) (*SnapshotFile, error) {
+	f, err := snapshotFiles.Open(path.Join(snapshotDir, name+".json.gz"))
+	if err != nil {
+		return nil, fmt.Errorf("unknown snapshot %q (available: %s)", name, strings.Join(Snapshots(), ", "))
+	}
+	defer f.Close()
+
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("could not read snapshot %q: %w", name, err)
+	}
+	var sf SnapshotFile
+	if err := json.NewDecoder(zr).Decode(&sf); err != nil {
+		return nil, fmt.Errorf("could not read snapshot %q: %w", name, err)
+	}
+	return &sf, nil
 }
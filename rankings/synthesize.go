@@ -0,0 +1,68 @@
+package rankings
+
+import (
+	"math"
+	"math/rand"
+
+	fakefeeder "github.com/emojitracker/emojitrack-fakefeeder"
+)
+
+// LongTail configures the scores synthesized for emoji by Synthesize. The
+// missing emoji are placed in a random order, and the emoji at position k
+// (from 1) is given a score of Max / k^Alpha, i.e. a Zipf-like long tail.
+type LongTail struct {
+	Max   int64   // score of the first synthesized emoji; if zero, the lowest score in the seed data
+	Alpha float64 // exponent controlling how quickly scores fall off; if zero, 1
+	Seed  int64   // seed for the random order; if zero, the order varies each time
+}
+
+// Synthesize returns rs along with a synthesized ranking for every emoji in the
+// bundled Unicode data (see Unicode) which is missing from rs, such as newer
+// emoji, ZWJ sequences and skin tone variants. Synthesized scores are at least
+// 1, and follow the long tail distribution lt.
+func Synthesize(rs []fakefeeder.Ranking, lt LongTail) []fakefeeder.Ranking {
+	have := make(map[string]bool, len(rs))
+	var lowest int64
+	for i, r := range rs {
+		have[r.ID] = true
+		if i == 0 || r.Score < lowest {
+			lowest = r.Score
+		}
+	}
+
+	var missing []fakefeeder.Ranking
+	for _, e := range Unicode() {
+		if !have[e.ID] {
+			missing = append(missing, e)
+		}
+	}
+
+	max := lt.Max
+	if max <= 0 {
+		max = lowest
+	}
+	alpha := lt.Alpha
+	if alpha == 0 {
+		alpha = 1
+	}
+	seed := lt.Seed
+	if seed == 0 {
+		seed = rand.Int63()
+	}
+	rng := rand.New(rand.NewSource(seed))
+	rng.Shuffle(len(missing), func(i, j int) {
+		missing[i], missing[j] = missing[j], missing[i]
+	})
+
+	results := make([]fakefeeder.Ranking, 0, len(rs)+len(missing))
+	results = append(results, rs...)
+	for k, e := range missing {
+		score := int64(math.Round(float64(max) / math.Pow(float64(k+1), alpha)))
+		if score < 1 {
+			score = 1
+		}
+		e.Score = score
+		results = append(results, e)
+	}
+	return results
+}
@@ -0,0 +1,340 @@
+package fakefeeder
+
+import (
+	"bufio"
+	"errors"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// ClientName is the name the feeder's connections should identify with to
+// redis (e.g. via redis.DialClientName), so that RedisSink.DropConnections can
+// distinguish them from other clients.
+const ClientName = "fakefeeder"
+
+// RedisSink is a Sink writing to a redis instance in exactly the same format as
+// emojitrack-feeder. It also implements FaultSink.
+type RedisSink struct {
+	rp    *redis.Pool
+	depth atomic.Int64 // SinkConfig.HistoryDepth
+
+	// Namespace, if set before use, is prepended to all keys and channels
+	// written (see Namespaced), so that several feeders can share a redis.
+	// Consumers must be configured to match.
+	Namespace string
+}
+
+// NewRedisSink returns a RedisSink utilizing a configured redis.Pool p.
+func NewRedisSink(p *redis.Pool) *RedisSink {
+	return &RedisSink{rp: p}
+}
+
+// constants of redis commands to avoid potential runtime errors from typos :-)
+const (
+	rCLIENT  = "CLIENT"
+	rDEL     = "DEL"
+	rEXEC    = "EXEC"
+	rEXISTS  = "EXISTS"
+	rLPUSH   = "LPUSH"
+	rLTRIM   = "LTRIM"
+	rMULTI   = "MULTI"
+	rPUBLISH = "PUBLISH"
+	rZADD    = "ZADD"
+	rZINCRBY = "ZINCRBY"
+)
+
+// The redis keys and pub/sub channels written by RedisSink, as read by the
+// other components of Emojitracker. See Namespaced for the names used with a
+// RedisSink.Namespace.
+const (
+	ScoreKey            = "emojitrack_score"      // sorted set of scores by emoji ID
+	TweetKeyPrefix      = "emojitrack_tweets_"    // + emoji ID, list of recent tweets, newest first
+	ScoreUpdatesChannel = "stream.score_updates"  // emoji ID of each update
+	TweetUpdatesPrefix  = "stream.tweet_updates." // + emoji ID, tweet of each update
+)
+
+// Namespaced returns key, one of the keys or channels (or prefixes) above, as
+// written by a RedisSink with the given Namespace: unchanged if it is empty,
+// and otherwise prefixed with the namespace and a colon.
+func Namespaced(namespace, key string) string {
+	if namespace == "" {
+		return key
+	}
+	return namespace + ":" + key
+}
+
+// key returns key within the namespace of s.
+func (s *RedisSink) key(key string) string {
+	return Namespaced(s.Namespace, key)
+}
+
+// Prepare implements Sink by loading the Lua update script.
+func (s *RedisSink) Prepare(cfg SinkConfig) error {
+	s.depth.Store(int64(cfg.HistoryDepth))
+
+	c := s.rp.Get()
+	defer c.Close()
+	return redisError(updateScript.Load(c), func(err error) error {
+		return &ScriptLoadError{Err: err}
+	})
+}
+
+// Seeded implements Sink by checking for the existence of the score key.
+func (s *RedisSink) Seeded() (bool, error) {
+	c := s.rp.Get()
+	defer c.Close()
+	seeded, err := redis.Bool(c.Do(rEXISTS, s.key(ScoreKey)))
+	return seeded, transportError(err)
+}
+
+// SeedScores implements Sink.
+func (s *RedisSink) SeedScores(rs []Ranking) error {
+	cmds := make([]seedCommand, 0, len(rs))
+	for _, r := range rs {
+		cmds = append(cmds, seedCommand{
+			id:   r.ID,
+			args: []interface{}{rZADD, s.key(ScoreKey), r.Score, r.ID},
+		})
+	}
+	return s.execSeed(cmds)
+}
+
+// SeedTweets implements Sink.
+func (s *RedisSink) SeedTweets(hs []TweetHistory) error {
+	depth := s.depth.Load()
+	cmds := make([]seedCommand, 0, 2*len(hs))
+	for _, h := range hs {
+		if len(h.Tweets) == 0 {
+			continue
+		}
+		tKey := s.key(TweetKeyPrefix) + h.ID
+		args := make([]interface{}, 0, len(h.Tweets)+2)
+		args = append(args, rLPUSH, tKey)
+		for _, t := range h.Tweets {
+			args = append(args, t)
+		}
+		cmds = append(cmds,
+			seedCommand{id: h.ID, args: args},
+			seedCommand{id: h.ID, args: []interface{}{rLTRIM, tKey, 0, depth - 1}},
+		)
+	}
+	return s.execSeed(cmds)
+}
+
+// seedCommand is a single redis command seeding data for emoji id. The first
+// of args is the command name.
+type seedCommand struct {
+	id   string
+	args []interface{}
+}
+
+// execSeed runs cmds in a single MULTI/EXEC transaction, checking the reply to
+// every command. Failures of individual commands, either when being queued or
+// when executed, are returned as SeedFailures.
+func (s *RedisSink) execSeed(cmds []seedCommand) error {
+	c := s.rp.Get()
+	defer c.Close()
+
+	if err := c.Send(rMULTI); err != nil {
+		return transportError(err)
+	}
+	for _, cmd := range cmds {
+		if err := c.Send(cmd.args[0].(string), cmd.args[1:]...); err != nil {
+			return transportError(err)
+		}
+	}
+	if err := c.Send(rEXEC); err != nil {
+		return transportError(err)
+	}
+	if err := c.Flush(); err != nil {
+		return transportError(err)
+	}
+
+	if _, err := c.Receive(); err != nil { // MULTI
+		return transportError(err)
+	}
+
+	// commands which fail to queue (e.g. wrong number of arguments) cause the
+	// whole transaction to be discarded
+	var failures SeedFailures
+	queued := make([]bool, len(cmds))
+	for i, cmd := range cmds {
+		_, err := c.Receive()
+		var redisErr redis.Error
+		switch {
+		case err == nil:
+			queued[i] = true
+		case errors.As(err, &redisErr):
+			failures = append(failures, SeedFailure{ID: cmd.id, Err: err})
+		default:
+			return transportError(err)
+		}
+	}
+
+	replies, err := redis.Values(c.Receive()) // EXEC
+	if err != nil {
+		var redisErr redis.Error
+		if !errors.As(err, &redisErr) {
+			return transportError(err)
+		}
+		for i, cmd := range cmds {
+			if queued[i] {
+				failures = append(failures, SeedFailure{ID: cmd.id, Err: err})
+			}
+		}
+		return failures
+	}
+
+	// commands can also fail individually at execution (e.g. WRONGTYPE)
+	for i, r := range replies {
+		if err, ok := r.(redis.Error); ok {
+			failures = append(failures, SeedFailure{ID: cmds[i].id, Err: err})
+		}
+	}
+	if len(failures) > 0 {
+		return failures
+	}
+	return nil
+}
+
+// Clear implements Sink.
+func (s *RedisSink) Clear(ids []string) error {
+	c := s.rp.Get()
+	defer c.Close()
+
+	if err := c.Send(rMULTI); err != nil {
+		return transportError(err)
+	}
+	if err := c.Send(rDEL, s.key(ScoreKey)); err != nil {
+		return transportError(err)
+	}
+	for _, id := range ids {
+		if err := c.Send(rDEL, s.key(TweetKeyPrefix)+id); err != nil {
+			return transportError(err)
+		}
+	}
+	_, err := c.Do(rEXEC)
+	return transportError(err)
+}
+
+// Update implements Sink using the Lua update script. If redis has lost its
+// script cache (e.g. after a restart), the script is sent again in full.
+func (s *RedisSink) Update(id string, tweet []byte) error {
+	c := s.rp.Get()
+	defer c.Close()
+
+	_, err := updateScript.Do(c, id, tweet, s.depth.Load(), s.key(""))
+	return redisError(err, func(err error) error {
+		return &ScriptError{Err: err}
+	})
+}
+
+// Increment implements FaultSink.
+func (s *RedisSink) Increment(id string) error {
+	c := s.rp.Get()
+	defer c.Close()
+
+	_, err := c.Do(rZINCRBY, s.key(ScoreKey), 1, id)
+	return transportError(err)
+}
+
+// Publish implements FaultSink.
+func (s *RedisSink) Publish(id string, tweet []byte) error {
+	c := s.rp.Get()
+	defer c.Close()
+
+	c.Send(rPUBLISH, s.key(ScoreUpdatesChannel), id)
+	c.Send(rPUBLISH, s.key(TweetUpdatesPrefix)+id, tweet)
+	if err := c.Flush(); err != nil {
+		return transportError(err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := c.Receive(); err != nil {
+			return transportError(err)
+		}
+	}
+	return nil
+}
+
+// DropConnections implements FaultSink by killing all connections to redis
+// identifying as ClientName, including those currently idle in the pool.
+func (s *RedisSink) DropConnections() error {
+	c := s.rp.Get()
+	defer c.Close()
+
+	list, err := redis.String(c.Do(rCLIENT, "LIST"))
+	if err != nil {
+		return transportError(err)
+	}
+	var ids []string
+	sc := bufio.NewScanner(strings.NewReader(list))
+	for sc.Scan() {
+		var id, name string
+		for _, field := range strings.Fields(sc.Text()) {
+			switch {
+			case strings.HasPrefix(field, "id="):
+				id = strings.TrimPrefix(field, "id=")
+			case strings.HasPrefix(field, "name="):
+				name = strings.TrimPrefix(field, "name=")
+			}
+		}
+		if name == ClientName && id != "" {
+			ids = append(ids, id)
+		}
+	}
+
+	// kill everyone else first, since killing ourself ends the conversation
+	selfID, err := redis.Int64(c.Do(rCLIENT, "ID"))
+	if err != nil {
+		return transportError(err)
+	}
+	self := strconv.FormatInt(selfID, 10)
+	for _, id := range ids {
+		if id == self {
+			continue
+		}
+		if _, err := c.Do(rCLIENT, "KILL", "ID", id); err != nil {
+			return transportError(err)
+		}
+	}
+	c.Do(rCLIENT, "KILL", "ID", self, "SKIPME", "no")
+	return nil
+}
+
+// This is the same update script used in emojitrack-feeder, except that the
+// number of recent tweets kept is passed as an argument rather than fixed at
+// 10, as is the prefix for all keys and channels (empty unless namespaced).
+var updateScript = redis.NewScript(0, `
+-- Updates the server whenever a new emoji is seen in a tweet
+--
+-- Putting this in a script enables us to save some bandwidth by not
+-- transmitting any redundant data to the server, as we can calculate the
+-- appropriate key names there and re-use data that goes to multiple
+-- destinations.
+
+local uid      = ARGV[1]   -- unified codepoint ID
+local tinyjson = ARGV[2]   -- json blob representing the ensmallened tweet
+local depth    = tonumber(ARGV[3]) -- number of recent tweets to keep
+local ns       = ARGV[4]   -- namespace prefix for keys and channels
+
+-- increment the score in a sorted set
+redis.call('ZINCRBY', ns .. 'emojitrack_score', 1, uid)
+
+-- stream the fact that the score was updated
+redis.call('PUBLISH', ns .. 'stream.score_updates', uid)
+
+-- for each emoji char, store the most recent tweets in a list
+local tweet_details_key = ns .. "emojitrack_tweets_" .. uid
+redis.call('LPUSH', tweet_details_key, tinyjson)
+redis.call('LTRIM', tweet_details_key, 0, depth - 1)
+
+-- also stream all tweet updates to named streams by char
+local stream_details_key = ns .. "stream.tweet_updates." .. uid
+redis.call('PUBLISH', stream_details_key, tinyjson)
+
+-- return ok status
+return 1
+`)
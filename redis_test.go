@@ -0,0 +1,56 @@
+package fakefeeder
+
+import (
+	"errors"
+	"net"
+	"syscall"
+	"testing"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// brokenConn is a redis.Conn whose connection has failed, as after a reset.
+type brokenConn struct{ err error }
+
+func (c brokenConn) Close() error                                   { return nil }
+func (c brokenConn) Err() error                                     { return c.err }
+func (c brokenConn) Do(string, ...interface{}) (interface{}, error) { return nil, c.err }
+func (c brokenConn) Send(string, ...interface{}) error              { return c.err }
+func (c brokenConn) Flush() error                                   { return c.err }
+func (c brokenConn) Receive() (interface{}, error)                  { return nil, c.err }
+
+// brokenSink returns a RedisSink whose every connection fails with err.
+func brokenSink(err error) *RedisSink {
+	return NewRedisSink(&redis.Pool{
+		Dial: func() (redis.Conn, error) { return brokenConn{err}, nil },
+	})
+}
+
+func TestRedisSinkClearTransportError(t *testing.T) {
+	reset := &net.OpError{Op: "write", Net: "tcp", Err: syscall.ECONNRESET}
+	err := brokenSink(reset).Clear([]string{"1F602", "2764"})
+
+	var transportErr *TransportError
+	if !errors.As(err, &transportErr) {
+		t.Fatalf("Clear() = %v, want a *TransportError", err)
+	}
+	if !errors.Is(err, reset) {
+		t.Errorf("Clear() = %v, want it to wrap %v", err, reset)
+	}
+	if got := ErrorClass(err); got != "network" {
+		t.Errorf("ErrorClass(%v) = %q, want network", err, got)
+	}
+}
+
+func TestRedisSinkDropConnectionsTransportError(t *testing.T) {
+	reset := &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}
+	err := brokenSink(reset).DropConnections()
+
+	var transportErr *TransportError
+	if !errors.As(err, &transportErr) {
+		t.Fatalf("DropConnections() = %v, want a *TransportError", err)
+	}
+	if !IsTransient(err) {
+		t.Errorf("IsTransient(%v) = false, want true", err)
+	}
+}
@@ -0,0 +1,147 @@
+package fakefeeder
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+)
+
+// SeedProgress reports the progress of seeding initial data to a sink.
+type SeedProgress struct {
+	What  string // the data being seeded, "scores" or "tweets"
+	Done  int    // number of emoji seeded so far, successfully or not
+	Total int    // total number of emoji to seed
+}
+
+// init seeds the initial scores and tweets to the sink.
+func (f *Feeder) init() error {
+	err := f.seedBatches("scores", f.sink.SeedScores)
+	if err != nil {
+		return &SeedError{What: "scores", Err: err}
+	}
+	err = f.seedBatches("tweets", func(batch []Ranking) error {
+		return f.sink.SeedTweets(f.seedTweets(batch))
+	})
+	if err != nil {
+		return &SeedError{What: "tweets", Err: err}
+	}
+
+	return nil
+}
+
+// seedTweets generate initial random tweets for each emoji in batch, such
+// that initial buffer for historical window is filled. If a HistoryWindow is
+// configured, the tweets are backdated randomly over it.
+func (f *Feeder) seedTweets(batch []Ranking) []TweetHistory {
+	depth := f.opts.sinkConfig().HistoryDepth
+	window := f.opts.HistoryWindow
+	hs := make([]TweetHistory, 0, len(batch))
+	for _, r := range batch {
+		h := TweetHistory{ID: r.ID, Tweets: make([][]byte, 0, depth)}
+		if window <= 0 {
+			for i := 0; i < depth; i++ {
+				t := randomTweetForEmoji(r)
+				h.Tweets = append(h.Tweets, t.MustEncode())
+			}
+		} else {
+			for _, at := range backdates(depth, window) {
+				t := backdatedTweetForEmoji(r, at)
+				h.Tweets = append(h.Tweets, t.MustEncode())
+			}
+		}
+		hs = append(hs, h)
+	}
+	return hs
+}
+
+// backdates returns n random times within the window before now, oldest first.
+func backdates(n int, window time.Duration) []time.Time {
+	offsets := make([]int64, n)
+	for i := range offsets {
+		offsets[i] = rand.Int63n(int64(window))
+	}
+	sort.Slice(offsets, func(i, j int) bool { return offsets[i] > offsets[j] })
+
+	now := time.Now()
+	times := make([]time.Time, n)
+	for i, o := range offsets {
+		times[i] = now.Add(-time.Duration(o))
+	}
+	return times
+}
+
+// seedBatches calls seed with the seed data split into batches of at most
+// SeedChunkSize, using up to SeedParallelism goroutines.
+//
+// SeedFailures returned for individual batches are combined and returned once
+// all batches have been attempted, whereas any other error stops seeding of
+// further batches and is returned.
+func (f *Feeder) seedBatches(what string, seed func([]Ranking) error) error {
+	size := f.opts.SeedChunkSize
+	if size <= 0 {
+		size = DefaultSeedChunkSize
+	}
+	parallelism := f.opts.SeedParallelism
+	if parallelism <= 0 {
+		parallelism = 1
+	}
+
+	var (
+		mu       sync.Mutex
+		done     int
+		failures SeedFailures
+		firstErr error
+	)
+	batches := make(chan []Ranking)
+	var wg sync.WaitGroup
+	for i := 0; i < parallelism; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range batches {
+				err := seed(batch)
+
+				mu.Lock()
+				var fs SeedFailures
+				switch {
+				case errors.As(err, &fs):
+					failures = append(failures, fs...)
+				case err != nil && firstErr == nil:
+					firstErr = err
+				}
+				done += len(batch)
+				if f.opts.SeedProgress != nil {
+					f.opts.SeedProgress(SeedProgress{What: what, Done: done, Total: len(f.seed)})
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for i := 0; i < len(f.seed); i += size {
+		mu.Lock()
+		stop := firstErr != nil
+		mu.Unlock()
+		if stop {
+			break
+		}
+
+		end := i + size
+		if end > len(f.seed) {
+			end = len(f.seed)
+		}
+		batches <- f.seed[i:end]
+	}
+	close(batches)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	if len(failures) > 0 {
+		return failures
+	}
+	return nil
+}
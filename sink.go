@@ -0,0 +1,56 @@
+package fakefeeder
+
+// Sink is a destination for the data generated by a Feeder. RedisSink is the
+// implementation matching the behavior of emojitrack-feeder; other
+// implementations are primarily useful for testing and fault injection.
+//
+// Implementations must be safe for concurrent use by multiple goroutines.
+type Sink interface {
+	// Prepare readies the sink for updates with cfg, e.g. by loading scripts.
+	// It is called again when reconnecting after the sink has been down.
+	Prepare(cfg SinkConfig) error
+	// Seeded reports whether the sink currently holds seed data, so that it
+	// can be re-seeded if it comes back empty after being down.
+	Seeded() (bool, error)
+	// SeedScores sets the score for each of rs, overwriting existing values.
+	// A Feeder calls it in batches of at most Options.SeedChunkSize, and
+	// possibly concurrently. If seeding fails for some of rs, SeedFailures
+	// should be returned.
+	SeedScores(rs []Ranking) error
+	// SeedTweets adds historical tweets for each emoji in hs, oldest first,
+	// keeping only the most recent SinkConfig.HistoryDepth. It is called in
+	// batches in the same way as SeedScores.
+	SeedTweets(hs []TweetHistory) error
+	// Clear removes the score and tweet history for each of the emoji ids.
+	Clear(ids []string) error
+	// Update records a single occurrence of emoji id in the JSON encoded
+	// tweet: incrementing its score, adding tweet to its recent history
+	// (trimmed to SinkConfig.HistoryDepth), and publishing both to the
+	// streams.
+	Update(id string, tweet []byte) error
+}
+
+// SinkConfig holds the settings a Feeder requires to be applied consistently
+// by its Sink.
+type SinkConfig struct {
+	HistoryDepth int // number of recent tweets kept for each emoji
+}
+
+// TweetHistory is a list of JSON encoded tweets for the emoji with ID.
+type TweetHistory struct {
+	ID     string
+	Tweets [][]byte
+}
+
+// FaultSink is implemented by sinks that support the faults injected by a
+// ChaosSink beyond simple latency, dropped and duplicated updates.
+type FaultSink interface {
+	Sink
+	// Increment increments the score for emoji id without publishing it.
+	Increment(id string) error
+	// Publish publishes an update for emoji id and the JSON encoded tweet to
+	// the streams without incrementing its score or adding to its history.
+	Publish(id string, tweet []byte) error
+	// DropConnections forcibly closes all underlying connections.
+	DropConnections() error
+}
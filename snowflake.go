@@ -0,0 +1,95 @@
+package fakefeeder
+
+import (
+	"sync"
+	"time"
+)
+
+// Twitter snowflake ID layout: 41 bits of milliseconds since the Twitter epoch,
+// 10 bits of worker ID, and 12 bits of per-millisecond sequence number.
+const (
+	twitterEpoch = 1288834974657 // ms since Unix epoch (2010-11-04T01:42:54.657Z)
+
+	snowflakeWorkerBits = 10
+	snowflakeSeqBits    = 12
+	snowflakeSeqMask    = 1<<snowflakeSeqBits - 1
+	snowflakeTimeShift  = snowflakeWorkerBits + snowflakeSeqBits
+)
+
+// snowflake generates unique, monotonically increasing tweet IDs using the same
+// layout as Twitter, so the timestamp embedded in each ID can be recovered by
+// consumers. It is safe for concurrent use.
+type snowflake struct {
+	worker int64
+
+	mu     sync.Mutex
+	lastMS int64
+	seq    int64
+	atSeq  map[int64]int64 // next sequence for each millisecond issued by at
+}
+
+// next returns a new ID for a tweet created at approximately time t, along with
+// the exact time encoded in that ID (millisecond precision).
+//
+// If the sequence space for a millisecond is exhausted, or the clock moves
+// backwards, the ID is issued for the most recent millisecond seen instead, so
+// IDs never collide or go backwards.
+func (s *snowflake) next(t time.Time) (int64, time.Time) {
+	ms := t.UnixNano()/int64(time.Millisecond) - twitterEpoch
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if ms <= s.lastMS {
+		ms = s.lastMS
+		s.seq = (s.seq + 1) & snowflakeSeqMask
+		if s.seq == 0 {
+			ms++ // sequence overflow, borrow the next millisecond
+		}
+	} else {
+		s.seq = 0
+	}
+	s.lastMS = ms
+
+	id := ms<<snowflakeTimeShift | s.worker<<snowflakeSeqBits | s.seq
+	return id, snowflakeTime(ms)
+}
+
+// at returns a new ID for a tweet created at time t, which may be in the past,
+// along with the exact time encoded in that ID (millisecond precision).
+//
+// Unlike next, IDs are not monotonic, so a generator should be used with either
+// next or at exclusively. The sequence is tracked separately for every
+// millisecond, so IDs never collide; if it is exhausted, the ID is issued for
+// the next millisecond instead. The generator remembers each millisecond used,
+// so it should only be used for a bounded number of IDs, such as seeding.
+func (s *snowflake) at(t time.Time) (int64, time.Time) {
+	ms := t.UnixNano()/int64(time.Millisecond) - twitterEpoch
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.atSeq == nil {
+		s.atSeq = make(map[int64]int64)
+	}
+	for s.atSeq[ms] > snowflakeSeqMask {
+		ms++ // sequence exhausted, carry over to the next millisecond
+	}
+	seq := s.atSeq[ms]
+	s.atSeq[ms] = seq + 1
+
+	id := ms<<snowflakeTimeShift | s.worker<<snowflakeSeqBits | seq
+	return id, snowflakeTime(ms)
+}
+
+// snowflakeTime converts a millisecond offset from the Twitter epoch to a
+// time.Time.
+func snowflakeTime(ms int64) time.Time {
+	return time.Unix(0, (ms+twitterEpoch)*int64(time.Millisecond))
+}
+
+// TweetIDTime returns the creation time embedded in a snowflake tweet ID, such
+// as those of the tweets generated by a Feeder (millisecond precision).
+func TweetIDTime(id int64) time.Time {
+	return snowflakeTime(id >> snowflakeTimeShift)
+}
@@ -0,0 +1,156 @@
+package fakefeeder
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestSnowflakeLayout(t *testing.T) {
+	epoch := time.UnixMilli(twitterEpoch)
+	tests := []struct {
+		name   string
+		at     time.Time
+		worker int64
+		want   int64
+	}{
+		{"one millisecond", epoch.Add(time.Millisecond), 0, 1 << 22},
+		{"worker", epoch.Add(time.Millisecond), 1, 1<<22 | 1<<12},
+		{"max worker", epoch.Add(time.Millisecond), 1<<snowflakeWorkerBits - 1, 1<<22 | (1<<snowflakeWorkerBits-1)<<12},
+		{"2020", time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), 0, 1212161512043446272},
+		{"2020 worker 5", time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), 5, 1212161512043466752},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &snowflake{worker: tt.worker}
+			id, at := s.next(tt.at)
+			if id != tt.want {
+				t.Errorf("id = %d (%b), want %d (%b)", id, id, tt.want, tt.want)
+			}
+			if !at.Equal(tt.at) {
+				t.Errorf("time = %v, want %v", at, tt.at)
+			}
+			if got := id >> snowflakeTimeShift; got != tt.at.UnixMilli()-twitterEpoch {
+				t.Errorf("time bits = %d, want %d", got, tt.at.UnixMilli()-twitterEpoch)
+			}
+			if got := id >> snowflakeSeqBits & (1<<snowflakeWorkerBits - 1); got != tt.worker {
+				t.Errorf("worker bits = %d, want %d", got, tt.worker)
+			}
+		})
+	}
+}
+
+func TestTweetIDTimeRoundTrip(t *testing.T) {
+	for _, at := range []time.Time{
+		time.UnixMilli(twitterEpoch),
+		time.Date(2014, 3, 1, 12, 0, 0, 0, time.UTC),
+		time.Now().Truncate(time.Millisecond),
+		time.Date(2080, 1, 1, 0, 0, 0, 999e6, time.UTC), // within the 41 bit range
+	} {
+		for _, s := range []*snowflake{{}, {worker: 1}} {
+			id, encoded := s.next(at)
+			if got := TweetIDTime(id); !got.Equal(encoded) || !got.Equal(at) {
+				t.Errorf("TweetIDTime(next(%v)) = %v, encoded %v", at, got, encoded)
+			}
+			id, _ = s.at(at)
+			if got := TweetIDTime(id); !got.Equal(at) {
+				t.Errorf("TweetIDTime(at(%v)) = %v", at, got)
+			}
+		}
+	}
+
+	// sub-millisecond precision is truncated
+	at := time.Date(2020, 1, 1, 0, 0, 0, 1234567, time.UTC)
+	id, _ := (&snowflake{}).next(at)
+	if got, want := TweetIDTime(id), at.Truncate(time.Millisecond); !got.Equal(want) {
+		t.Errorf("TweetIDTime = %v, want %v", got, want)
+	}
+}
+
+func TestSnowflakeSequenceRollover(t *testing.T) {
+	s := &snowflake{}
+	at := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	ms := at.UnixMilli() - twitterEpoch
+
+	var last int64
+	for i := 0; i <= snowflakeSeqMask; i++ {
+		id, encoded := s.next(at)
+		if i > 0 && id <= last {
+			t.Fatalf("id %d: %d not after %d", i, id, last)
+		}
+		if id&snowflakeSeqMask != int64(i) {
+			t.Fatalf("id %d: sequence %d", i, id&snowflakeSeqMask)
+		}
+		if !encoded.Equal(at) {
+			t.Fatalf("id %d: encoded time %v, want %v", i, encoded, at)
+		}
+		last = id
+	}
+
+	// the 4097th ID in one millisecond borrows the next millisecond
+	id, encoded := s.next(at)
+	if id <= last {
+		t.Errorf("rollover id %d not after %d", id, last)
+	}
+	if got := id >> snowflakeTimeShift; got != ms+1 {
+		t.Errorf("rollover time bits = %d, want %d", got, ms+1)
+	}
+	if id&snowflakeSeqMask != 0 {
+		t.Errorf("rollover sequence = %d, want 0", id&snowflakeSeqMask)
+	}
+	if want := at.Add(time.Millisecond); !encoded.Equal(want) || !TweetIDTime(id).Equal(want) {
+		t.Errorf("rollover time = %v (from id %v), want %v", encoded, TweetIDTime(id), want)
+	}
+
+	// later IDs for the original millisecond stay after it, never going back
+	next, _ := s.next(at)
+	if next <= id {
+		t.Errorf("id %d after rollover not after %d", next, id)
+	}
+}
+
+func TestSnowflakeClockBackwards(t *testing.T) {
+	s := &snowflake{}
+	at := time.Date(2020, 1, 1, 0, 0, 1, 0, time.UTC)
+	first, _ := s.next(at)
+	second, encoded := s.next(at.Add(-time.Second))
+	if second <= first {
+		t.Errorf("id %d after clock went backwards not after %d", second, first)
+	}
+	if !encoded.Equal(at) {
+		t.Errorf("encoded time = %v, want last seen %v", encoded, at)
+	}
+}
+
+func TestSnowflakeAtUnique(t *testing.T) {
+	s := &snowflake{worker: 1}
+	at := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	ms := at.UnixMilli() - twitterEpoch
+
+	// exhaust the sequence of a single millisecond, then carry over into the
+	// next, which must not reuse the sequence already issued there
+	first, _ := s.at(at.Add(time.Millisecond))
+	seen := map[int64]bool{first: true}
+	for i := 0; i < 2*(snowflakeSeqMask+1); i++ {
+		id, encoded := s.at(at)
+		if seen[id] {
+			t.Fatalf("id %d: duplicate %d", i, id)
+		}
+		seen[id] = true
+		if got := id >> snowflakeTimeShift; got < ms || got > ms+2 {
+			t.Fatalf("id %d: time bits %d, want %d to %d", i, got, ms, ms+2)
+		}
+		if !TweetIDTime(id).Equal(encoded) {
+			t.Fatalf("id %d: encoded time %v, want %v", i, encoded, TweetIDTime(id))
+		}
+	}
+
+	// many random times in a short window
+	for i := 0; i < 100000; i++ {
+		id, _ := s.at(at.Add(time.Duration(rand.Int63n(int64(10 * time.Millisecond)))))
+		if seen[id] {
+			t.Fatalf("random id %d: duplicate %d", i, id)
+		}
+		seen[id] = true
+	}
+}